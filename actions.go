@@ -6,13 +6,12 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
-
-	"github.com/dkaslovsky/nav/internal/sanitize"
 )
 
 func (m *model) Init() tea.Cmd {
-	return nil
+	return m.backgroundCmd()
 }
 
 func (m *model) View() string {
@@ -24,19 +23,52 @@ func (m *model) View() string {
 		return m.exitStr + "\n"
 	}
 	if m.modeHelp {
-		view = commands()
+		view = commands() + exCommandsUsage()
 	} else if m.modeDebug {
 		view = m.debugView()
+	} else if m.modeBasket {
+		view = m.basketView()
+	} else if m.modeSets {
+		view = m.setsView()
+	} else if m.modePrompt && len(m.prompt.candidates) > 0 {
+		view = m.candidatesView()
+	} else if m.modeDual {
+		view = m.dualView()
 	} else {
 		view = m.normalView()
 	}
-	if m.hideStatusBar {
-		return view
+	if m.showTabBar() && !m.modeHelp && !m.modeDebug {
+		view = m.tabBar() + "\n" + view
+	}
+	if !m.hideStatusBar {
+		view = strings.Join([]string{view, m.statusBar()}, "\n")
 	}
-	return strings.Join([]string{view, m.statusBar()}, "\n")
+	// The clipboard sequence is written at the end of the last line, which the renderer does not
+	// rewrite while it is unchanged, so that the sequence is written once.
+	return view + m.clipboardSeq
 }
 
 func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	_, cmd := m.update(msg)
+	return m, tea.Batch(cmd, m.backgroundCmd())
+}
+
+// backgroundCmd returns commands for loading information about the current state that is not
+// required for the initial display.
+func (m *model) backgroundCmd() tea.Cmd {
+	if m.modeExit {
+		return nil
+	}
+	cmds := []tea.Cmd{m.diskUsageCmd()}
+	for _, p := range m.displayedPanes() {
+		m.inPane(p, func() {
+			cmds = append(cmds, m.gitStatusCmd(), m.dirSizeCmd(), m.previewCmd())
+		})
+	}
+	return tea.Batch(append(cmds, m.spinnerCmd())...)
+}
+
+func (m *model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	esc := false
 
 	switch msg := msg.(type) {
@@ -46,7 +78,52 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, result.cmd
 		}
 
+	case gitStatusMsg:
+		if result := actionGitStatus(m, msg, esc); !result.noop {
+			return m, result.cmd
+		}
+
+	case dirSizeMsg:
+		if result := actionDirSize(m, msg, esc); !result.noop {
+			return m, result.cmd
+		}
+
+	case diskUsageMsg:
+		if result := actionDiskUsage(m, msg, esc); !result.noop {
+			return m, result.cmd
+		}
+
+	case previewMsg:
+		if result := actionPreview(m, msg, esc); !result.noop {
+			return m, result.cmd
+		}
+
+	case spinner.TickMsg:
+		if result := actionSpinnerTick(m, msg, esc); !result.noop {
+			return m, result.cmd
+		}
+
+	case yankMsg:
+		if result := actionYank(m, msg, esc); !result.noop {
+			return m, result.cmd
+		}
+
+	case tea.MouseMsg:
+		if result := actionMouse(m, msg, esc); !result.noop {
+			return m, result.cmd
+		}
+
+	case execFinishedMsg:
+		if result := actionExecFinished(m, msg, esc); !result.noop {
+			return m, result.cmd
+		}
+
 	case tea.KeyMsg:
+		m.info = ""
+		m.clipboardSeq = ""
+
+		// A count applies only to the key that follows it.
+		m.count, m.countPrefix = m.countPrefix, 0
 
 		// Remapped escape logic
 		if key.Matches(msg, m.esc.key) {
@@ -57,6 +134,13 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.esc.reset()
 		}
 
+		// Prompts are handled before quitting to allow any character in the input.
+		if m.modePrompt {
+			if result := actionModePrompt(m, msg, esc); !result.noop {
+				return m, result.cmd
+			}
+		}
+
 		if result := actionQuit(m, msg, esc); !result.noop {
 			return m, result.cmd
 		}
@@ -73,12 +157,48 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		if m.modeBasket {
+			if result := actionModeBasket(m, msg, esc); !result.noop {
+				return m, result.cmd
+			}
+		}
+
+		if m.modeSets {
+			if result := actionModeSets(m, msg, esc); !result.noop {
+				return m, result.cmd
+			}
+		}
+
 		if m.modeSearch {
 			if result := actionModeSearch(m, msg, esc); !result.noop {
 				return m, result.cmd
 			}
 		}
 
+		if m.modeJump {
+			if result := actionModeJump(m, msg, esc); !result.noop {
+				return m, result.cmd
+			}
+		}
+
+		if m.normalMode() || m.modeVisual {
+			if result := actionCount(m, msg, esc); !result.noop {
+				return m, result.cmd
+			}
+		}
+
+		if m.keyPrefix != "" {
+			if result := actionKeyPrefix(m, msg, esc); !result.noop {
+				return m, result.cmd
+			}
+		}
+
+		if m.modeVisual {
+			if result := actionModeVisual(m, msg, esc); !result.noop {
+				return m, result.cmd
+			}
+		}
+
 		if m.modeMarks {
 			if result := actionModeMarks(m, msg, esc); !result.noop {
 				return m, result.cmd
@@ -120,6 +240,83 @@ func actionWindowResize(m *model, msg tea.WindowSizeMsg, esc bool) actionResult
 	return newActionResult(nil)
 }
 
+func actionGitStatus(m *model, msg gitStatusMsg, esc bool) actionResult {
+	for _, t := range m.tabs {
+		for _, p := range t.panes {
+			if msg.path == p.path {
+				p.git = msg.status
+			}
+		}
+	}
+	return newActionResult(nil)
+}
+
+func actionDirSize(m *model, msg dirSizeMsg, esc bool) actionResult {
+	delete(m.dirSizesPending, msg.path)
+	m.dirSizes[msg.path] = &dirSizeResult{modTime: msg.modTime, size: msg.size}
+	if m.sortMode == sortModeSize {
+		m.sort()
+	}
+	return newActionResult(nil)
+}
+
+func actionDiskUsage(m *model, msg diskUsageMsg, esc bool) actionResult {
+	if msg.root != m.diskUsageScanning {
+		return newActionResult(nil)
+	}
+	m.diskUsageScanning = ""
+	if msg.err != nil {
+		m.setError(msg.err, "failed to scan disk usage")
+		return newActionResult(nil)
+	}
+	m.diskUsagePath = msg.root
+	m.diskUsageRoot = msg.node
+	m.sort()
+	return newActionResult(nil)
+}
+
+func actionSpinnerTick(m *model, msg spinner.TickMsg, esc bool) actionResult {
+	// Stop ticking when no background computations are in progress.
+	if !m.busy() {
+		m.spinning = false
+		return newActionResult(nil)
+	}
+	var cmd tea.Cmd
+	m.spinner, cmd = m.spinner.Update(msg)
+	return newActionResult(cmd)
+}
+
+func actionPreview(m *model, msg previewMsg, esc bool) actionResult {
+	delete(m.previewsPending, msg.path)
+	m.previews[msg.path] = &preview{entries: msg.entries, err: msg.err}
+	return newActionResult(nil)
+}
+
+func actionExecFinished(m *model, msg execFinishedMsg, esc bool) actionResult {
+	if msg.err != nil {
+		m.setError(msg.err, msg.status)
+	}
+
+	// Reload the directory and previews and invalidate the ignore rules to reflect any changes made
+	// by the command.
+	m.gitignore = nil
+	m.previews = make(map[string]*preview)
+	if err := m.relist(); err != nil {
+		m.setError(err, err.Error())
+	}
+	return newActionResult(nil)
+}
+
+func actionYank(m *model, msg yankMsg, esc bool) actionResult {
+	m.clipboardSeq = msg.sequence
+	if msg.err != nil {
+		m.setError(msg.err, "failed to copy to clipboard")
+		return newActionResult(nil)
+	}
+	m.info = fmt.Sprintf("copied %d path(s) to clipboard", msg.count)
+	return newActionResult(nil)
+}
+
 func actionQuit(m *model, msg tea.KeyMsg, esc bool) actionResult {
 	if key.Matches(msg, keyQuit) {
 		m.setExitWithCode("", 2)
@@ -178,14 +375,15 @@ func actionModeSearch(m *model, msg tea.KeyMsg, esc bool) actionResult {
 		return newActionResult(nil)
 
 	case key.Matches(msg, keyBack):
-		if len(m.search) > 0 {
-			m.search = m.search[:len(m.search)-1]
+		if m.search.value != "" {
+			m.search.backspace()
 			return newActionResult(nil)
 		}
 
 		m.saveCursor()
 
-		_, m.search = filepath.Split(m.path)
+		_, name := filepath.Split(m.path)
+		m.search.set(name)
 		path, err := filepath.Abs(filepath.Join(m.path, ".."))
 		if err != nil {
 			m.setError(err, "failed to evaluate path")
@@ -207,35 +405,121 @@ func actionModeSearch(m *model, msg tea.KeyMsg, esc bool) actionResult {
 		return newActionResult(cmd)
 
 	case key.Matches(msg, keyTab):
-		if m.displayed != 1 {
-			return newActionResult(nil)
-		}
-		_, cmd := m.searchSelectAction()
-		return newActionResult(cmd)
+		return newActionResult(m.completeSearch())
 
 	case key.Matches(msg, keyFileSeparator):
 		if m.displayed != 1 {
-			m.search += keyStringFirst(keyFileSeparator)
+			m.search.insert(keyStringFirst(keyFileSeparator))
 			return newActionResult(nil)
 		}
 		if selected, err := m.selected(); err == nil && selected.hasMode(entryModeFile) {
-			m.search += keyStringFirst(keyFileSeparator)
+			m.search.insert(keyStringFirst(keyFileSeparator))
 			return newActionResult(nil)
 		}
 		_, cmd := m.searchSelectAction()
 		return newActionResult(cmd)
 
-	default:
-		if msg.Type == tea.KeyRunes || key.Matches(msg, keySpace) {
-			m.search += string(msg.Runes)
-			return newActionResult(nil)
-		}
+	case m.search.edit(msg, searchLineKeys):
+		return newActionResult(nil)
 
 	}
 
 	return newActionResultNoop()
 }
 
+func actionModePrompt(m *model, msg tea.KeyMsg, esc bool) actionResult {
+	if esc || key.Matches(msg, keyEsc) {
+		m.closePrompt()
+		return newActionResult(nil)
+	}
+	m.prompt.candidates = nil
+
+	switch {
+
+	// Do not allow remapped escape key character as part of the input.
+	case key.Matches(msg, m.esc.key):
+		return newActionResult(nil)
+
+	// Editing keys are matched before named keys so that typed text such as "tab" is inserted.
+	case m.prompt.input.edit(msg, promptLineKeys):
+		return newActionResult(nil)
+
+	case key.Matches(msg, keySelect):
+		return newActionResult(m.submitPrompt(m.prompt.input.value))
+
+	case key.Matches(msg, keyTab):
+		if m.prompt.complete != nil {
+			m.prompt.input.set(m.prompt.complete(m, m.prompt.input.value))
+		}
+		return newActionResult(nil)
+
+	case key.Matches(msg, keyHistoryPrev):
+		if value, ok := m.prompt.history.prev(); ok {
+			m.prompt.input.set(value)
+		}
+		return newActionResult(nil)
+
+	case key.Matches(msg, keyHistoryNext):
+		if value, ok := m.prompt.history.next(); ok {
+			m.prompt.input.set(value)
+		}
+		return newActionResult(nil)
+
+	// Allow quitting from a prompt.
+	case key.Matches(msg, keyQuit):
+		return newActionResultNoop()
+
+	}
+
+	// Unconditional return to disable all other functionality.
+	return newActionResult(nil)
+}
+
+func actionKeyPrefix(m *model, msg tea.KeyMsg, esc bool) actionResult {
+	prefix := m.keyPrefix
+	m.keyPrefix = ""
+
+	switch prefix {
+
+	case keyStringFirst(keyFirst):
+		if key.Matches(msg, keyFirst) {
+			// A count moves to the entry with that number instead.
+			m.moveToIndex(m.repeat() - 1)
+			m.saveCursor()
+		}
+
+	case keyStringFirst(keyMarkType):
+		switch {
+		case key.Matches(msg, keyMarkTypeDir):
+			m.markType(entryModeDir)
+		case key.Matches(msg, keyMarkTypeExec):
+			m.markType(entryModeExec)
+		case key.Matches(msg, keyMarkTypeSymlink):
+			m.markType(entryModeSymlink)
+		}
+
+	}
+
+	// Unconditional return to consume the key following the prefix.
+	return newActionResult(nil)
+}
+
+// maxCount limits the count typed before a command.
+const maxCount = 99999
+
+func actionCount(m *model, msg tea.KeyMsg, esc bool) actionResult {
+	if msg.Type != tea.KeyRunes || msg.Alt || len(msg.Runes) != 1 {
+		return newActionResultNoop()
+	}
+	// A leading zero is handled as a command rather than as part of a count.
+	r := msg.Runes[0]
+	if r < '0' || r > '9' || (r == '0' && m.count == 0) {
+		return newActionResultNoop()
+	}
+	m.countPrefix = min(10*m.count+int(r-'0'), maxCount)
+	return newActionResult(nil)
+}
+
 func actionModeMarks(m *model, msg tea.KeyMsg, esc bool) actionResult {
 	if key.Matches(msg, keyMarkAll) {
 		err := m.toggleMarkAll()
@@ -259,96 +543,131 @@ func actionModeGeneral(m *model, msg tea.KeyMsg, esc bool) actionResult {
 	// Return
 
 	case key.Matches(msg, keyReturnDirectory):
-		m.setExit(sanitize.SanitizeOutputPath(m.path))
-		if m.modeSubshell {
-			fmt.Print(m.exitStr)
-		}
-		return newActionResult(tea.Quit)
+		return newActionResult(m.returnDirectory())
 
 	case key.Matches(msg, keyReturnSelected):
-		selecteds := []*entry{}
-		paths := []string{}
+		paths, err := m.returnedPaths()
+		if err != nil {
+			m.setError(err, "failed to select entry")
+			return newActionResult(nil)
+		}
+		return newActionResult(m.returnPaths(paths))
 
-		if m.modeMarks {
-			for _, entryIdx := range m.marks {
-				if entryIdx < len(m.entries) {
-					selecteds = append(selecteds, m.entries[entryIdx])
-				}
-			}
-			sortEntries(selecteds)
-		} else {
-			selected, err := m.selected()
+	// Copy to clipboard
+
+	case key.Matches(msg, keyYankSelected):
+		if m.normalMode() {
+			paths, err := m.returnedPaths()
 			if err != nil {
 				m.setError(err, "failed to select entry")
 				return newActionResult(nil)
 			}
-			selecteds = append(selecteds, selected)
-		}
-
-		for _, selected := range selecteds {
-			var path string
-			if selected.hasMode(entryModeSymlink) {
-				sl, err := followSymlink(m.path, selected)
-				if err != nil {
-					m.setError(err, "failed to evaluate symlink")
-					return newActionResult(nil)
-				}
-				path = sanitize.SanitizeOutputPath(sl.absPath)
-			} else {
-				path = sanitize.SanitizeOutputPath(filepath.Join(m.path, selected.Name()))
-			}
-			paths = append(paths, path)
+			return newActionResult(yank(paths))
 		}
 
-		m.setExit(strings.Join(paths, " "))
-		if m.modeSubshell {
-			fmt.Print(m.exitStr)
+	case key.Matches(msg, keyYankDirectory):
+		if m.normalMode() {
+			return newActionResult(yank([]string{m.path}))
 		}
-		return newActionResult(tea.Quit)
 
 	// Cursor
 
 	case key.Matches(msg, keyUp):
-		m.moveUp()
+		for range m.repeat() {
+			m.moveUp()
+		}
 
 	case key.Matches(msg, keyDown):
-		m.moveDown()
+		for range m.repeat() {
+			m.moveDown()
+		}
 
 	case key.Matches(msg, keyLeft):
-		m.moveLeft()
+		if m.modeColumns && !m.modeTree {
+			_, cmd := m.backAction()
+			return newActionResult(cmd)
+		}
+		if m.modeTree {
+			if err := m.collapse(); err != nil {
+				m.setError(err, "failed to collapse directory")
+			}
+			return newActionResult(nil)
+		}
+		for range m.repeat() {
+			m.moveLeft()
+		}
 
 	case key.Matches(msg, keyRight):
-		m.moveRight()
+		if m.modeColumns && !m.modeTree {
+			// Only directories are entered to avoid opening files when moving right.
+			if _, child := m.previewPaths(); child != "" {
+				_, cmd := m.selectAction()
+				return newActionResult(cmd)
+			}
+			return newActionResult(nil)
+		}
+		if m.modeTree {
+			if err := m.expand(); err != nil {
+				m.setError(err, "failed to expand directory")
+			}
+			return newActionResult(nil)
+		}
+		for range m.repeat() {
+			m.moveRight()
+		}
 
-	// Selectors
+	case key.Matches(msg, keyFirst):
+		m.keyPrefix = keyStringFirst(keyFirst)
+		// Keep the count for the key completing the command.
+		m.countPrefix = m.count
 
-	case key.Matches(msg, keySelect):
-		m.clearMarks()
-		_, cmd := m.selectAction()
-		return newActionResult(cmd)
+	case key.Matches(msg, keyLast):
+		if m.count > 0 {
+			m.moveToIndex(m.count - 1)
+		} else {
+			m.moveLast()
+		}
 
-	case key.Matches(msg, keyBack):
-		m.saveCursor()
+	case key.Matches(msg, keyRowStart):
+		m.moveRowStart()
 
-		path, err := filepath.Abs(filepath.Join(m.path, ".."))
-		if err != nil {
-			m.setError(err, "failed to evaluate path")
-			return newActionResult(nil)
+	case key.Matches(msg, keyRowEnd):
+		m.moveRowEnd()
+
+	case key.Matches(msg, keyScreenTop):
+		m.moveScreenTop()
+
+	case key.Matches(msg, keyScreenMiddle):
+		m.moveScreenMiddle()
+
+	case key.Matches(msg, keyScreenBottom):
+		m.moveScreenBottom()
+
+	case key.Matches(msg, keyColumnPrev):
+		for range m.repeat() {
+			m.moveColumnPrev()
 		}
-		m.setPath(path)
 
-		err = m.list()
-		if err != nil {
-			m.restorePath()
-			m.setError(err, err.Error())
-			return newActionResult(nil)
+	case key.Matches(msg, keyColumnNext):
+		for range m.repeat() {
+			m.moveColumnNext()
 		}
 
-		m.clearSearch()
-		m.clearMarks()
+	case key.Matches(msg, keyPageDown):
+		m.movePageDown(m.repeat())
 
-		// Return to ensure the cursor is not re-saved using the updated path.
-		return newActionResult(nil)
+	case key.Matches(msg, keyPageUp):
+		m.movePageUp(m.repeat())
+
+	// Selectors
+
+	case key.Matches(msg, keySelect):
+		_, cmd := m.selectAction()
+		return newActionResult(cmd)
+
+	case key.Matches(msg, keyBack):
+		_, cmd := m.backAction()
+		return newActionResult(cmd)
 
 	case key.Matches(msg, keyMark):
 		if m.normalMode() {
@@ -371,20 +690,83 @@ func actionModeGeneral(m *model, msg tea.KeyMsg, esc bool) actionResult {
 
 	// Return current directory
 	case key.Matches(msg, keyReturnDirectory):
-		m.setExit(sanitize.SanitizeOutputPath(m.path))
-		if m.modeSubshell {
-			fmt.Print(m.exitStr)
-		}
-		return newActionResult(tea.Quit)
+		return newActionResult(m.returnDirectory())
 
 	// Change modes
 
 	case key.Matches(msg, keyModeHelp):
 		m.modeHelp = true
 
+	case key.Matches(msg, keyMarkPattern):
+		if m.normalMode() {
+			m.openMarkPatternPrompt(true)
+		}
+
+	case key.Matches(msg, keyUnmarkPattern):
+		if m.normalMode() {
+			m.openMarkPatternPrompt(false)
+		}
+
+	case key.Matches(msg, keyInvertMarks):
+		if m.normalMode() {
+			m.invertMarks()
+		}
+
+	case key.Matches(msg, keyMarkType):
+		if m.normalMode() {
+			m.keyPrefix = keyStringFirst(keyMarkType)
+		}
+
+	case key.Matches(msg, keyVisual):
+		if m.normalMode() && m.displayed > 0 {
+			m.startVisual()
+		}
+
+	case key.Matches(msg, keyJump):
+		if m.normalMode() && m.displayed > 0 {
+			m.startJump()
+		}
+
+	case key.Matches(msg, keySaveSet):
+		if m.normalMode() {
+			m.openSaveSetPrompt()
+		}
+
+	case key.Matches(msg, keySets):
+		if m.normalMode() {
+			if err := m.openSets(); err != nil {
+				m.setError(err, "failed to list sets")
+			}
+		}
+
+	case key.Matches(msg, keyBasket):
+		if m.normalMode() {
+			m.modeBasket = true
+			m.basketCursor = 0
+		}
+
 	case key.Matches(msg, keyModeSearch):
 		m.modeSearch = true
-		m.clearMarks()
+
+	case key.Matches(msg, keyShellCommand):
+		if m.normalMode() {
+			m.openShellCommandPrompt()
+		}
+
+	case key.Matches(msg, keyExCommand):
+		if m.normalMode() {
+			m.openExPrompt()
+		}
+
+	case key.Matches(msg, keyGoTo):
+		if m.normalMode() {
+			m.openGoToPrompt()
+		}
+
+	case key.Matches(msg, keySubshell):
+		if m.normalMode() {
+			return newActionResult(m.runSubshell())
+		}
 
 	// Toggles
 
@@ -397,6 +779,84 @@ func actionModeGeneral(m *model, msg tea.KeyMsg, esc bool) actionResult {
 	case key.Matches(msg, keyToggleList):
 		m.modeList = !m.modeList
 
+	case key.Matches(msg, keyToggleGitignore):
+		m.modeHideGitignore = !m.modeHideGitignore
+
+	case key.Matches(msg, keyToggleDirSizes):
+		m.modeDirSizes = !m.modeDirSizes
+		m.sort()
+
+	case key.Matches(msg, keySort):
+		m.sortMode = m.sortMode.next()
+		m.sort()
+
+	case key.Matches(msg, keyToggleDiskUsage):
+		m.modeDiskUsage = !m.modeDiskUsage
+		m.sort()
+
+	case key.Matches(msg, keyToggleTree):
+		m.modeTree = !m.modeTree
+		if err := m.relist(); err != nil {
+			m.setError(err, err.Error())
+		}
+
+	case key.Matches(msg, keyToggleDual):
+		if err := m.toggleDual(); err != nil {
+			m.setError(err, err.Error())
+		}
+
+	case key.Matches(msg, keySwitchPane):
+		if m.normalMode() {
+			m.switchPane()
+			// Return to ensure the cursor is not saved to the newly focused pane.
+			return newActionResult(nil)
+		}
+
+	case key.Matches(msg, keyNewTab):
+		if m.normalMode() {
+			if err := m.openTab(); err != nil {
+				m.setError(err, err.Error())
+			}
+			// Return to ensure the cursor is not saved to the new tab.
+			return newActionResult(nil)
+		}
+
+	case key.Matches(msg, keyCloseTab):
+		if m.normalMode() {
+			m.closeTab()
+			return newActionResult(nil)
+		}
+
+	case key.Matches(msg, keyNextTab):
+		if m.normalMode() {
+			m.cycleTab(1)
+			return newActionResult(nil)
+		}
+
+	case key.Matches(msg, keyPrevTab):
+		if m.normalMode() {
+			m.cycleTab(-1)
+			return newActionResult(nil)
+		}
+
+	case key.Matches(msg, keyCopy):
+		if m.normalMode() {
+			m.openCopyPrompt()
+		}
+
+	case key.Matches(msg, keyMove):
+		if m.normalMode() {
+			m.openMovePrompt()
+		}
+
+	case key.Matches(msg, keyToggleColumns):
+		m.modeColumns = !m.modeColumns
+
+	case key.Matches(msg, keyRescanDiskUsage):
+		if m.modeDiskUsage && m.diskUsageScanning == "" {
+			return newActionResult(m.rescanDiskUsage())
+		}
+
 	}
 
 	return newActionResultNoop()
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// basketView lists the marked paths of the current pane, which can be in any directory.
+func (m *model) basketView() string {
+	paths := m.markedPaths()
+	locationBar := barRendererLocation.Render(fmt.Sprintf("selection basket: %d marked", len(paths)))
+	if len(paths) == 0 {
+		return locationBar + "\n\n\t(no marked entries)\n"
+	}
+	if m.basketCursor >= len(paths) {
+		m.basketCursor = len(paths) - 1
+	}
+	if m.basketCursor < 0 {
+		m.basketCursor = 0
+	}
+
+	// Scroll to keep the cursor visible.
+	height := m.viewHeight()
+	start := 0
+	if m.basketCursor >= height {
+		start = m.basketCursor - height + 1
+	}
+
+	output := []string{locationBar}
+	for i := start; i < len(paths) && i < start+height; i++ {
+		name := displayPath(paths[i])
+		if info, err := os.Stat(paths[i]); err == nil && info.IsDir() {
+			name += fileSeparator
+		}
+		if i == m.basketCursor {
+			output = append(output, cursorRendererSelectedMarked.Render(name))
+		} else {
+			output = append(output, cursorRendererMarked.Render(name))
+		}
+	}
+	return strings.Join(output, "\n")
+}
+
+// basketSelected returns the marked path under the cursor in the selection basket.
+func (m *model) basketSelected() (string, bool) {
+	paths := m.markedPaths()
+	if m.basketCursor < 0 || m.basketCursor >= len(paths) {
+		return "", false
+	}
+	return paths[m.basketCursor], true
+}
+
+// goToPath navigates to the directory containing path with the cursor on its entry.
+func (m *model) goToPath(path string) error {
+	m.saveCursor()
+	m.setPath(filepath.Dir(path))
+	if err := m.list(); err != nil {
+		m.restorePath()
+		return err
+	}
+	m.clearSearch()
+	m.focusPath = path
+	return nil
+}
+
+func actionModeBasket(m *model, msg tea.KeyMsg, esc bool) actionResult {
+	switch {
+
+	case esc || key.Matches(msg, keyEsc) || key.Matches(msg, keyBasket):
+		m.modeBasket = false
+
+	case key.Matches(msg, keyUp):
+		m.basketCursor--
+
+	case key.Matches(msg, keyDown):
+		m.basketCursor++
+
+	case key.Matches(msg, keyMark):
+		if path, ok := m.basketSelected(); ok {
+			m.unmark(path)
+		}
+
+	case key.Matches(msg, keySelect):
+		if path, ok := m.basketSelected(); ok {
+			if err := m.goToPath(path); err != nil {
+				m.setError(err, err.Error())
+				return newActionResult(nil)
+			}
+			m.modeBasket = false
+			// Return to ensure the cursor is not re-saved using the updated path.
+			return newActionResult(nil)
+		}
+
+	// Allow returning from the basket.
+	case key.Matches(msg, keyReturnSelected), key.Matches(msg, keyReturnDirectory):
+		return newActionResultNoop()
+
+	}
+
+	// Unconditional return to disable all other functionality.
+	return newActionResult(nil)
+}
@@ -4,6 +4,7 @@ type cacheItem struct {
 	cursorPosition *position
 	entryToDisplay map[int]int
 	displayToEntry map[int]int
+	expanded       map[string]bool // Set of paths of directories expanded in tree mode.
 	columns        int
 	rows           int
 }
@@ -17,6 +18,7 @@ func newCacheItemWithPosition(pos *position) *cacheItem {
 		cursorPosition: pos,
 		entryToDisplay: make(map[int]int),
 		displayToEntry: make(map[int]int),
+		expanded:       make(map[string]bool),
 	}
 }
 
@@ -56,6 +58,19 @@ func (ci *cacheItem) lookupDisplayIndex(entryIdx int) (int, bool) {
 	return displayIdx, ok
 }
 
+// remapEntryIndexes updates the index mappings after the entries have been reordered using a map of
+// previous entry index to new entry index.
+func (ci *cacheItem) remapEntryIndexes(remap map[int]int) {
+	displayToEntry := ci.displayToEntry
+	ci.entryToDisplay = make(map[int]int)
+	ci.displayToEntry = make(map[int]int)
+	for displayIdx, entryIdx := range displayToEntry {
+		if newEntryIdx, ok := remap[entryIdx]; ok {
+			ci.addIndexPair(&indexPair{entry: newEntryIdx, display: displayIdx})
+		}
+	}
+}
+
 // cursorIndex returns the display index of cursor
 func (ci *cacheItem) cursorIndex() int {
 	return ci.cursorPosition.index(ci.rows)
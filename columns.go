@@ -0,0 +1,191 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// preview contains the entries of a directory displayed in a side pane of the columns layout.
+type preview struct {
+	entries []*entry
+	err     error
+}
+
+// previewMsg is the result of loading a preview.
+type previewMsg struct {
+	path    string
+	entries []*entry
+	err     error
+}
+
+// previewPaths returns the paths of the directories displayed in the side panes of the columns
+// layout: the parent directory and the directory under the cursor.
+func (m *model) previewPaths() (parent string, child string) {
+	if dir := filepath.Dir(m.path); dir != m.path {
+		parent = dir
+	}
+	if selected, err := m.selected(); err == nil {
+		if selected.hasMode(entryModeDir) {
+			child = selected.path()
+		} else if selected.hasMode(entryModeSymlink) {
+			if sl, err := followSymlink(selected); err == nil && sl.info.IsDir() {
+				child = sl.absPath
+			}
+		}
+	}
+	return parent, child
+}
+
+// previewCmd returns a command for loading the side panes of the columns layout that have not been
+// loaded.
+func (m *model) previewCmd() tea.Cmd {
+	if !m.modeColumns {
+		return nil
+	}
+
+	cmds := []tea.Cmd{}
+	parent, child := m.previewPaths()
+	for _, path := range []string{parent, child} {
+		if path == "" || m.previewsPending[path] {
+			continue
+		}
+		if _, found := m.previews[path]; found {
+			continue
+		}
+		m.previewsPending[path] = true
+		cmds = append(cmds, m.loadPreview(path))
+	}
+	return tea.Batch(cmds...)
+}
+
+// loadPreview returns a command for reading the entries of a directory in the background.
+func (m *model) loadPreview(path string) tea.Cmd {
+	return func() tea.Msg {
+		files, err := os.ReadDir(path)
+		if err != nil {
+			return previewMsg{path: path, err: err}
+		}
+
+		hiddenNames := readHiddenFile(path)
+
+		entries := []*entry{}
+		for _, file := range files {
+			ent, err := newEntry(file)
+			if err != nil {
+				continue
+			}
+			ent.dir = path
+			if m.hideEntry(ent, hiddenNames) {
+				ent.mode = ent.mode | entryModeHidden
+			}
+			entries = append(entries, ent)
+		}
+		return previewMsg{path: path, entries: entries}
+	}
+}
+
+// columnsWidths returns the widths of the parent, current, and child panes of the columns layout.
+func (m *model) columnsWidths() (parent int, current int, child int) {
+	width := m.paneWidth()
+	parent = width / 5
+	current = 2 * width / 5
+	child = width - parent - current
+	return parent, current, child
+}
+
+// columnsView arranges the rendered rows of the current directory between the parent directory,
+// with the current directory highlighted, and the contents of the directory under the cursor.
+func (m *model) columnsView(rows []string) []string {
+	parentWidth, currentWidth, childWidth := m.columnsWidths()
+	parent, child := m.previewPaths()
+
+	height := m.viewHeight()
+
+	parentRows := []string{}
+	if parent != "" {
+		parentRows = m.previewView(parent, m.path, parentWidth, height)
+	}
+	childRows := []string{}
+	if child != "" {
+		childRows = m.previewView(child, "", childWidth, height)
+	}
+
+	output := make([]string, max(len(rows), max(len(parentRows), len(childRows))))
+	for i := range output {
+		// Panes are separated by a space to avoid truncated names running together.
+		output[i] = fitWidth(rowAt(parentRows, i), parentWidth-1) + " " +
+			fitWidth(rowAt(rows, i), currentWidth-1) + " " +
+			fitWidth(rowAt(childRows, i), childWidth)
+	}
+	return output
+}
+
+// previewView renders the entries of a directory in a side pane, highlighting the entry with the
+// provided path.
+func (m *model) previewView(path string, highlight string, width int, height int) []string {
+	p, found := m.previews[path]
+	if !found {
+		return []string{"  (loading)"}
+	}
+	if p.err != nil {
+		return []string{"  (unreadable)"}
+	}
+
+	entries := []*entry{}
+	for _, ent := range p.entries {
+		// Previewed entries are not filtered by the search of the current directory.
+		if m.filterEntry(ent) == entryFilterHidden {
+			continue
+		}
+		entries = append(entries, ent)
+	}
+	if len(entries) == 0 {
+		return []string{"  (no entries)"}
+	}
+	m.sortEntries(entries)
+
+	displayNameOpts := m.entryDisplayNameOpts()
+	displayNames := []*displayName{}
+	for _, ent := range entries {
+		displayNames = append(displayNames, newDisplayName(ent, displayNameOpts...))
+	}
+	gridNames, layout := gridSingleColumn(displayNames, width, height)
+
+	// Scroll to keep the highlighted entry visible.
+	start := 0
+	for row := range entries {
+		if entries[row].path() == highlight && row >= height {
+			start = row - height + 1
+		}
+	}
+
+	output := []string{}
+	for row := start; row < layout.rows && row < start+height; row++ {
+		if entries[row].path() == highlight {
+			output = append(output, cursorRendererSelected.Render(gridNames[0][row]))
+		} else {
+			output = append(output, cursorRendererNormal.Render(gridNames[0][row]))
+		}
+	}
+	return output
+}
+
+// fitWidth truncates or pads a rendered string to the provided width.
+func fitWidth(s string, width int) string {
+	s = lipgloss.NewStyle().MaxWidth(width).Render(s)
+	if pad := width - lipgloss.Width(s); pad > 0 {
+		s += strings.Repeat(" ", pad)
+	}
+	return s
+}
+
+func rowAt(rows []string, i int) string {
+	if i < len(rows) {
+		return rows[i]
+	}
+	return ""
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dkaslovsky/nav/internal/shell"
+)
+
+const (
+	envNavDir   = "NAV_DIR"
+	envNavFile  = "NAV_FILE"
+	envNavFiles = "NAV_FILES"
+	envNavLevel = "NAV_LEVEL"
+)
+
+func (m *model) openShellCommandPrompt() {
+	m.openPrompt(newPrompt("SHELL", "!", (*model).runShellCommand))
+}
+
+// runShellCommand suspends the program and runs a shell command in the current directory after
+// expanding placeholders. The paths used for the placeholders are also exported as environment
+// variables.
+func (m *model) runShellCommand(command string) tea.Cmd {
+	if strings.TrimSpace(command) == "" {
+		return nil
+	}
+
+	file := ""
+	if selected, err := m.selected(); err == nil {
+		file = selected.path()
+	}
+	files := m.markedPaths()
+	if len(files) == 0 && file != "" {
+		files = []string{file}
+	}
+
+	cmd := shell.CommandWithPause(expandPlaceholders(command, m.path, file, files))
+	cmd.Dir = m.path
+	cmd.Env = append(os.Environ(),
+		envNavDir+"="+m.path,
+		envNavFile+"="+file,
+		envNavFiles+"="+strings.Join(files, "\n"),
+	)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return execFinishedMsg{err: err, status: "failed to run command"}
+	})
+}
+
+// runSubshell suspends the program and starts an interactive shell in the current directory. The
+// NAV_LEVEL environment variable is exported with the shell's nesting depth within nav.
+func (m *model) runSubshell() tea.Cmd {
+	level := 0
+	if l, err := strconv.Atoi(os.Getenv(envNavLevel)); err == nil {
+		level = l
+	}
+
+	cmd := exec.Command(shell.Interactive())
+	cmd.Dir = m.path
+	cmd.Env = append(os.Environ(),
+		envNavLevel+"="+strconv.Itoa(level+1),
+		envNavDir+"="+m.path,
+	)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		// The exit status of an interactive shell is that of its last command, so only a failure
+		// to start the shell is an error.
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			err = nil
+		}
+		return execFinishedMsg{err: err, status: "failed to run shell"}
+	})
+}
+
+// expandPlaceholders replaces placeholders in a command with quoted paths:
+//
+//	%f: the entry under the cursor
+//	%F: all marked entries, or the entry under the cursor if none are marked
+//	%d: the current directory
+//	%%: a literal "%"
+//
+// Unrecognized placeholders are left unchanged.
+func expandPlaceholders(command string, dir string, file string, files []string) string {
+	var b strings.Builder
+	for i := 0; i < len(command); i++ {
+		if command[i] != '%' || i == len(command)-1 {
+			b.WriteByte(command[i])
+			continue
+		}
+
+		i++
+		switch command[i] {
+		case 'f':
+			b.WriteString(shell.Quote(file))
+		case 'F':
+			quoted := make([]string, len(files))
+			for j, f := range files {
+				quoted[j] = shell.Quote(f)
+			}
+			b.WriteString(strings.Join(quoted, " "))
+		case 'd':
+			b.WriteString(shell.Quote(dir))
+		case '%':
+			b.WriteByte('%')
+		default:
+			b.WriteByte('%')
+			b.WriteByte(command[i])
+		}
+	}
+	return b.String()
+}
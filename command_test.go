@@ -0,0 +1,51 @@
+//go:build !windows
+
+package main
+
+import "testing"
+
+func TestExpandPlaceholders(t *testing.T) {
+	tests := map[string]struct {
+		command string
+		want    string
+	}{
+		"no_placeholders": {
+			command: "ls -l",
+			want:    "ls -l",
+		},
+		"file": {
+			command: "cat %f",
+			want:    "cat '/tmp/dir/it'\\''s'",
+		},
+		"files": {
+			command: "tar czf out.tgz %F",
+			want:    "tar czf out.tgz '/tmp/dir/a b' '/tmp/dir/it'\\''s'",
+		},
+		"dir": {
+			command: "cd %d && ls",
+			want:    "cd '/tmp/dir' && ls",
+		},
+		"literal_percent": {
+			command: "date +%%s",
+			want:    "date +%s",
+		},
+		"unrecognized_placeholder": {
+			command: "printf %s %f",
+			want:    "printf %s '/tmp/dir/it'\\''s'",
+		},
+		"trailing_percent": {
+			command: "echo 100%",
+			want:    "echo 100%",
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(tt *testing.T) {
+			got := expandPlaceholders(test.command, "/tmp/dir", "/tmp/dir/it's", []string{"/tmp/dir/a b", "/tmp/dir/it's"})
+			if got != test.want {
+				tt.Fatalf("expected %q, got %q", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	envConfig = "NAV_CONFIG"
+
+	configDirectiveOpen = "open"
+	configDirectiveHide = "hide"
+)
+
+// configPath returns the path of the configuration file, which can be overridden by setting the
+// NAV_CONFIG environment variable.
+func configPath() (string, error) {
+	if path := os.Getenv(envConfig); path != "" {
+		return path, nil
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, name, "config"), nil
+}
+
+// loadConfig reads the configuration file, if it exists, and applies its directives to the model.
+// The file is line based with blank lines and lines starting with "#" ignored. Each remaining line
+// is a directive followed by its arguments:
+//
+//	open <pattern> <command>
+//	hide <pattern>
+func (m *model) loadConfig() error {
+	path, err := configPath()
+	if err != nil {
+		return nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	lineNum := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		directive, args, _ := strings.Cut(line, " ")
+		args = strings.TrimSpace(args)
+
+		switch directive {
+		case configDirectiveOpen:
+			pattern, command, found := strings.Cut(args, " ")
+			command = strings.TrimSpace(command)
+			if !found || command == "" {
+				return fmt.Errorf("%s:%d: %s requires a pattern and a command", path, lineNum, directive)
+			}
+			m.openers = append(m.openers, &opener{pattern: pattern, command: command})
+		case configDirectiveHide:
+			if args == "" {
+				return fmt.Errorf("%s:%d: %s requires a pattern", path, lineNum, directive)
+			}
+			if _, err := filepath.Match(args, ""); err != nil {
+				return fmt.Errorf("%s:%d: invalid pattern %s: %w", path, lineNum, args, err)
+			}
+			m.hidePatterns = append(m.hidePatterns, args)
+		default:
+			return fmt.Errorf("%s:%d: unknown directive: %s", path, lineNum, directive)
+		}
+	}
+	return scanner.Err()
+}
@@ -85,3 +85,98 @@ func (m *model) moveRight() {
 		m.c = m.columns - 1
 	}
 }
+
+// moveToIndex moves the cursor to the entry at a display index, limited to the displayed entries.
+func (m *model) moveToIndex(idx int) {
+	if m.displayed == 0 || m.rows == 0 {
+		return
+	}
+	idx = max(0, min(idx, m.displayed-1))
+	m.setCursor(newPositionFromIndex(idx, m.rows))
+}
+
+// lastColumn returns the last column containing an entry, which can be less than the last column
+// of the layout.
+func (m *model) lastColumn() int {
+	return max(0, (m.displayed-1)/max(1, m.rows))
+}
+
+// lastRow returns the last row of a column containing an entry, accounting for a partially filled
+// last column.
+func (m *model) lastRow(c int) int {
+	return max(0, min(m.rows-1, m.displayed-1-c*m.rows))
+}
+
+func (m *model) moveFirst() {
+	m.moveToIndex(0)
+}
+
+func (m *model) moveLast() {
+	m.moveToIndex(m.displayed - 1)
+}
+
+func (m *model) moveRowStart() {
+	m.c = 0
+}
+
+func (m *model) moveRowEnd() {
+	m.c = m.lastColumn()
+	if index(m.c, m.r, m.rows) >= m.displayed {
+		// The last column does not extend to the current row.
+		m.c = max(0, m.c-1)
+	}
+}
+
+func (m *model) moveScreenTop() {
+	m.r = 0
+}
+
+func (m *model) moveScreenMiddle() {
+	m.r = m.lastRow(m.c) / 2
+}
+
+func (m *model) moveScreenBottom() {
+	m.r = m.lastRow(m.c)
+}
+
+// moveColumnPrev moves the cursor to the top of the column, or to the top of the previous column
+// if already at the top.
+func (m *model) moveColumnPrev() {
+	if m.r == 0 && m.c > 0 {
+		m.c--
+	}
+	m.r = 0
+}
+
+// moveColumnNext moves the cursor to the top of the next column, or to the last entry if in the
+// last column.
+func (m *model) moveColumnNext() {
+	if m.c >= m.lastColumn() {
+		m.r = m.lastRow(m.c)
+		return
+	}
+	m.c++
+	m.r = 0
+}
+
+// movePageDown moves the cursor forward by the number of rows that fit on the screen.
+func (m *model) movePageDown(pages int) {
+	m.moveToIndex(index(m.c, m.r, m.rows) + pages*m.pageSize())
+}
+
+// movePageUp moves the cursor back by the number of rows that fit on the screen.
+func (m *model) movePageUp(pages int) {
+	m.moveToIndex(index(m.c, m.r, m.rows) - pages*m.pageSize())
+}
+
+func (m *model) pageSize() int {
+	return max(1, min(m.rows, m.viewHeight()))
+}
+
+// repeat returns the count typed before the key being handled, defaulting to one.
+func (m *model) repeat() int {
+	if m.count > 0 {
+		return m.count
+	}
+	return 1
+}
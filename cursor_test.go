@@ -0,0 +1,50 @@
+package main
+
+import "testing"
+
+func TestMotions(t *testing.T) {
+	// A layout of 19 entries in 4 rows, which uses only 5 of 6 layout columns and partially fills
+	// the last used column:
+	//
+	//	0  4  8  12  16
+	//	1  5  9  13  17
+	//	2  6  10 14  18
+	//	3  7  11 15
+	tests := map[string]struct {
+		start    position
+		move     func(m *model)
+		expected position
+	}{
+		"first":                  {position{c: 3, r: 2}, (*model).moveFirst, position{c: 0, r: 0}},
+		"last":                   {position{c: 0, r: 0}, (*model).moveLast, position{c: 4, r: 2}},
+		"row_start":              {position{c: 3, r: 2}, (*model).moveRowStart, position{c: 0, r: 2}},
+		"row_end":                {position{c: 0, r: 1}, (*model).moveRowEnd, position{c: 4, r: 1}},
+		"row_end_partial_column": {position{c: 0, r: 3}, (*model).moveRowEnd, position{c: 3, r: 3}},
+		"screen_top":             {position{c: 2, r: 3}, (*model).moveScreenTop, position{c: 2, r: 0}},
+		"screen_middle":          {position{c: 2, r: 0}, (*model).moveScreenMiddle, position{c: 2, r: 1}},
+		"screen_bottom":          {position{c: 2, r: 0}, (*model).moveScreenBottom, position{c: 2, r: 3}},
+		"screen_bottom_partial":  {position{c: 4, r: 0}, (*model).moveScreenBottom, position{c: 4, r: 2}},
+		"column_next":            {position{c: 1, r: 2}, (*model).moveColumnNext, position{c: 2, r: 0}},
+		"column_next_last":       {position{c: 4, r: 0}, (*model).moveColumnNext, position{c: 4, r: 2}},
+		"column_prev":            {position{c: 2, r: 2}, (*model).moveColumnPrev, position{c: 2, r: 0}},
+		"column_prev_at_top":     {position{c: 2, r: 0}, (*model).moveColumnPrev, position{c: 1, r: 0}},
+		"column_prev_first":      {position{c: 0, r: 0}, (*model).moveColumnPrev, position{c: 0, r: 0}},
+		"page_down":              {position{c: 0, r: 1}, func(m *model) { m.movePageDown(2) }, position{c: 2, r: 1}},
+		"page_down_limit":        {position{c: 3, r: 3}, func(m *model) { m.movePageDown(1) }, position{c: 4, r: 2}},
+		"page_up":                {position{c: 3, r: 1}, func(m *model) { m.movePageUp(1) }, position{c: 2, r: 1}},
+		"page_up_limit":          {position{c: 0, r: 3}, func(m *model) { m.movePageUp(1) }, position{c: 0, r: 0}},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(tt *testing.T) {
+			m := newModel()
+			m.rows, m.columns, m.displayed = 4, 6, 19
+			m.setCursor(&test.start)
+			test.move(m)
+			if m.c != test.expected.c || m.r != test.expected.r {
+				tt.Fatalf("expected (%d, %d), got (%d, %d)", test.expected.c, test.expected.r, m.c, m.r)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"io/fs"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// dirSizeWorkers bounds the number of directory sizes computed concurrently.
+var dirSizeWorkers = make(chan struct{}, runtime.NumCPU())
+
+// dirSizeResult is a computed total size of a directory, valid while the directory's modification
+// time is unchanged.
+type dirSizeResult struct {
+	modTime time.Time
+	size    int64
+}
+
+// dirSizeMsg is sent when the total size of a directory has been computed.
+type dirSizeMsg struct {
+	path    string
+	modTime time.Time
+	size    int64
+}
+
+// dirSize returns the computed total size of the directory at path if available and current.
+func (m *model) dirSize(path string, info fs.FileInfo) (int64, bool) {
+	if !m.modeDirSizes || !info.IsDir() {
+		return 0, false
+	}
+	result, ok := m.dirSizes[path]
+	if !ok || !result.modTime.Equal(info.ModTime()) {
+		return 0, false
+	}
+	return result.size, true
+}
+
+// dirSizePending reports whether the total size of the directory at path is being computed.
+func (m *model) dirSizePending(path string) bool {
+	return m.modeDirSizes && m.dirSizesPending[path]
+}
+
+// dirSizeCmd computes the total sizes of directories in the current listing in the background,
+// skipping directories with current cached results or computations in progress.
+func (m *model) dirSizeCmd() tea.Cmd {
+	if !m.modeDirSizes {
+		return nil
+	}
+
+	cmds := []tea.Cmd{}
+	for _, ent := range m.entries {
+		path := ent.path()
+		if !ent.hasMode(entryModeDir) || m.dirSizesPending[path] {
+			continue
+		}
+		if _, ok := m.dirSize(path, ent.info); ok {
+			continue
+		}
+
+		m.dirSizesPending[path] = true
+		modTime := ent.info.ModTime()
+		cmds = append(cmds, func() tea.Msg {
+			dirSizeWorkers <- struct{}{}
+			defer func() { <-dirSizeWorkers }()
+			return dirSizeMsg{path: path, modTime: modTime, size: walkSize(path)}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// listSize returns the formatted size of an entry for list mode, showing the spinner for
+// directories with sizes being computed.
+func (m *model) listSize(path string, info fs.FileInfo) string {
+	if size, ok := m.dirSize(path, info); ok {
+		return byteCountSI(size)
+	}
+	if info.IsDir() && m.dirSizePending(path) {
+		return m.spinner.View()
+	}
+	return byteCountSI(info.Size())
+}
+
+// walkSize returns the total size of the files contained in a directory and all of its
+// subdirectories. Subdirectories that cannot be read are skipped.
+func walkSize(path string) int64 {
+	var size int64
+	_ = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if d != nil && d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
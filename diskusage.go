@@ -0,0 +1,176 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dkaslovsky/nav/internal/fileinfo"
+)
+
+const diskUsageBarWidth = 10
+
+// diskUsageNode is the cumulative size of a file or directory in a disk usage scan.
+type diskUsageNode struct {
+	size     int64
+	children map[string]*diskUsageNode // Nil for entries that are not directories.
+}
+
+// diskUsageMsg is sent when a disk usage scan has completed.
+type diskUsageMsg struct {
+	root string
+	node *diskUsageNode
+	err  error
+}
+
+// hardLink identifies a file with multiple hard links so that its size is only counted once.
+type hardLink struct {
+	dev uint64
+	ino uint64
+}
+
+// scanDiskUsage recursively computes the cumulative sizes of a directory and its contents.
+// Symlinks are not followed and subdirectories that cannot be read are counted as empty.
+func scanDiskUsage(path string, seen map[hardLink]bool) *diskUsageNode {
+	node := &diskUsageNode{children: make(map[string]*diskUsageNode)}
+
+	files, err := os.ReadDir(path)
+	if err != nil {
+		return node
+	}
+	for _, file := range files {
+		info, err := file.Info()
+		if err != nil {
+			continue
+		}
+
+		if file.IsDir() {
+			child := scanDiskUsage(filepath.Join(path, file.Name()), seen)
+			node.children[file.Name()] = child
+			node.size += child.size
+			continue
+		}
+
+		size := info.Size()
+		if dev, ino, ok := fileinfo.HardLink(info); ok {
+			link := hardLink{dev: dev, ino: ino}
+			if seen[link] {
+				size = 0
+			}
+			seen[link] = true
+		}
+		node.children[file.Name()] = &diskUsageNode{size: size}
+		node.size += size
+	}
+	return node
+}
+
+// diskUsageCmd scans the current directory in the background when disk usage mode is enabled and
+// the current directory is not contained in the existing scan.
+func (m *model) diskUsageCmd() tea.Cmd {
+	if !m.modeDiskUsage || m.diskUsageScanning != "" {
+		return nil
+	}
+	if m.diskUsageRoot != nil {
+		if _, ok := m.diskUsageNode(m.path); ok {
+			return nil
+		}
+	}
+	return m.rescanDiskUsage()
+}
+
+// rescanDiskUsage starts a background disk usage scan of the current directory.
+func (m *model) rescanDiskUsage() tea.Cmd {
+	root := m.path
+	m.diskUsageScanning = root
+	return func() tea.Msg {
+		if _, err := os.Stat(root); err != nil {
+			return diskUsageMsg{root: root, err: err}
+		}
+		return diskUsageMsg{root: root, node: scanDiskUsage(root, make(map[hardLink]bool))}
+	}
+}
+
+// diskUsageNode returns the scanned node for a path contained in the current scan.
+func (m *model) diskUsageNode(path string) (*diskUsageNode, bool) {
+	if m.diskUsageRoot == nil {
+		return nil, false
+	}
+	rel, err := filepath.Rel(m.diskUsagePath, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+fileSeparator) {
+		return nil, false
+	}
+
+	node := m.diskUsageRoot
+	if rel == "." {
+		return node, true
+	}
+	for _, name := range strings.Split(rel, fileSeparator) {
+		child, ok := node.children[name]
+		if !ok {
+			return nil, false
+		}
+		node = child
+	}
+	return node, true
+}
+
+// diskUsageSize returns the cumulative size of the file or directory at path, or zero if it is
+// not in the current scan.
+func (m *model) diskUsageSize(path string) int64 {
+	if node, ok := m.diskUsageNode(path); ok {
+		return node.size
+	}
+	return 0
+}
+
+// sortDiskUsage performs an in-place sort of a slice of entries by cumulative size and
+// alphabetically for entries of equal size.
+func (m *model) sortDiskUsage(entries []*entry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		iSize, jSize := m.diskUsageSize(entries[i].path()), m.diskUsageSize(entries[j].path())
+		if iSize != jSize {
+			return iSize > jSize
+		}
+		return entries[i].Name() < entries[j].Name()
+	})
+}
+
+// diskUsageInfo returns a bar, percentage, and cumulative size of the file or directory at path
+// relative to the current directory.
+func (m *model) diskUsageInfo(path string) string {
+	total := int64(0)
+	if node, ok := m.diskUsageNode(m.path); ok {
+		total = node.size
+	}
+	size := m.diskUsageSize(path)
+
+	fraction := 0.0
+	if total > 0 {
+		fraction = float64(size) / float64(total)
+	}
+	filled := int(fraction*diskUsageBarWidth + 0.5)
+
+	return fmt.Sprintf(
+		"[%s%s] %5.1f%% %8s  ",
+		strings.Repeat("#", filled),
+		strings.Repeat(" ", diskUsageBarWidth-filled),
+		100*fraction,
+		byteCountSI(size),
+	)
+}
+
+// diskUsageStatus returns the state of the disk usage scan for the location bar.
+func (m *model) diskUsageStatus() string {
+	if m.diskUsageScanning != "" {
+		return fmt.Sprintf("scanning %s", m.spinner.View())
+	}
+	if node, ok := m.diskUsageNode(m.path); ok {
+		return fmt.Sprintf("total %s", byteCountSI(node.size))
+	}
+	return ""
+}
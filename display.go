@@ -7,8 +7,10 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/dkaslovsky/nav/internal/fileinfo"
+	"github.com/dkaslovsky/nav/internal/gitstatus"
 )
 
 // displayName contains a formatted name and effective length for display in the terminal.
@@ -28,20 +30,34 @@ func (d *displayName) Len() int {
 // newDisplayName constructs a displayName from an entry and provided functional options.
 func newDisplayName(e *entry, opts ...displayNameOption) *displayName {
 	c := &displayNameConfig{
+		path:      e.path(),
 		name:      e.Name(),
+		guide:     "",
 		nameExtra: "",
 		trailing:  "",
 		color:     colorGray,
 		listInfo:  "",
+		usageInfo: "",
+		markers:   "",
 	}
 
 	for _, opt := range opts {
 		opt(c, e.mode, e.info)
 	}
 
+	// Markers are separated from the name and are colored separately when a color is set.
+	markers, markersLen := "", 0
+	if c.markers != "" {
+		markers = " " + c.markers
+		markersLen = len(markers)
+		if c.markersColor != "" {
+			markers = fmt.Sprintf(" %s%s%s", c.markersColor, c.markers, colorReset)
+		}
+	}
+
 	return &displayName{
-		name: fmt.Sprintf("%s%s%s%s%s%s", c.listInfo, c.color, c.name, colorReset, c.trailing, c.nameExtra),
-		len:  len(c.name) + len(c.trailing) + len(c.nameExtra),
+		name: fmt.Sprintf("%s%s%s%s%s%s%s%s%s", c.usageInfo, c.listInfo, c.guide, c.color, c.name, colorReset, c.trailing, c.nameExtra, markers),
+		len:  utf8.RuneCountInString(c.guide) + len(c.name) + len(c.trailing) + len(c.nameExtra) + markersLen,
 	}
 }
 
@@ -53,16 +69,22 @@ const (
 	colorGreen   color = "\033[32m"
 	colorGray    color = "\033[37m"
 	colorMagenta color = "\033[35m"
+	colorRed     color = "\033[31m"
 	colorYellow  color = "\033[33m"
 )
 
 // displayNameConfig contains configuration values for constructing an entry's display name.
 type displayNameConfig struct {
-	color     color
-	name      string
-	nameExtra string
-	trailing  string
-	listInfo  string
+	color        color
+	path         string
+	name         string
+	guide        string
+	nameExtra    string
+	trailing     string
+	listInfo     string
+	usageInfo    string
+	markers      string
+	markersColor color
 }
 
 // displayNameOption is a functional option for setting displayNameConfig values.
@@ -80,15 +102,16 @@ func displayNameWithColor() displayNameOption {
 		case mode.has(entryModeExec):
 			c.color = colorGreen
 		}
+		c.markersColor = colorRed
 	}
 }
 
-func displayNameWithFollowSymlink(path string) displayNameOption {
+func displayNameWithFollowSymlink() displayNameOption {
 	return func(c *displayNameConfig, mode entryMode, info fs.FileInfo) {
 		if !mode.has(entryModeSymlink) {
 			return
 		}
-		if followedName, err := filepath.EvalSymlinks(filepath.Join(path, c.name)); err == nil {
+		if followedName, err := filepath.EvalSymlinks(c.path); err == nil {
 			if userHomeDir, err := os.UserHomeDir(); err == nil {
 				followedName = strings.Replace(followedName, userHomeDir, "~", 1)
 			}
@@ -97,7 +120,13 @@ func displayNameWithFollowSymlink(path string) displayNameOption {
 	}
 }
 
-func displayNameWithList() displayNameOption {
+func displayNameWithDiskUsage(usage func(path string) string) displayNameOption {
+	return func(c *displayNameConfig, mode entryMode, info fs.FileInfo) {
+		c.usageInfo = usage(c.path)
+	}
+}
+
+func displayNameWithList(size func(path string, info fs.FileInfo) string) displayNameOption {
 	return func(c *displayNameConfig, mode entryMode, info fs.FileInfo) {
 		user := "-"
 		if u, err := fileinfo.UserName(info); err == nil {
@@ -113,12 +142,25 @@ func displayNameWithList() displayNameOption {
 			info.Mode(),
 			user,
 			group,
-			byteCountSI(info.Size()),
+			size(c.path, info),
 			formatModTime(info.ModTime(), time.Now().Year()),
 		)
 	}
 }
 
+func displayNameWithGitStatus(status *gitstatus.Status) displayNameOption {
+	return func(c *displayNameConfig, mode entryMode, info fs.FileInfo) {
+		flags := status.Lookup(c.path, mode.has(entryModeDir))
+		c.markers = flags.Markers()
+	}
+}
+
+func displayNameWithTreeGuide(guide string) displayNameOption {
+	return func(c *displayNameConfig, mode entryMode, info fs.FileInfo) {
+		c.guide = guide
+	}
+}
+
 func displayNameWithTrailing() displayNameOption {
 	return func(c *displayNameConfig, mode entryMode, info fs.FileInfo) {
 		switch {
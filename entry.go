@@ -11,8 +11,10 @@ import (
 
 type entry struct {
 	fs.DirEntry
-	mode entryMode
-	info fs.FileInfo
+	mode  entryMode
+	info  fs.FileInfo
+	dir   string // Directory containing the entry.
+	depth int    // Depth of the entry below the listed directory in tree mode.
 }
 
 func newEntry(dirEntry fs.DirEntry) (*entry, error) {
@@ -30,6 +32,11 @@ func newEntry(dirEntry fs.DirEntry) (*entry, error) {
 	return e, nil
 }
 
+// path returns the path of the entry.
+func (e *entry) path() string {
+	return filepath.Join(e.dir, e.Name())
+}
+
 const maskExec = 0o111
 
 func (e *entry) setMode() {
@@ -90,11 +97,11 @@ type symlink struct {
 	info    fs.FileInfo
 }
 
-func followSymlink(path string, e *entry) (*symlink, error) {
+func followSymlink(e *entry) (*symlink, error) {
 	if !e.hasMode(entryModeSymlink) {
 		return nil, fmt.Errorf("cannot follow non-symlink entry: %s", e.Name())
 	}
-	followed, err := filepath.EvalSymlinks(filepath.Join(path, e.Name()))
+	followed, err := filepath.EvalSymlinks(e.path())
 	if err != nil {
 		return nil, err
 	}
@@ -114,7 +121,15 @@ func followSymlink(path string, e *entry) (*symlink, error) {
 // - files
 // - hidden files
 func sortEntries(entries []*entry) {
-	sort.Slice(entries, func(i, j int) bool {
+	sortEntriesWith(entries, func(iEntry, jEntry *entry) bool {
+		return iEntry.Name() < jEntry.Name()
+	})
+}
+
+// sortEntriesWith performs an in-place sort of a slice of entries by type (mode) as described for
+// sortEntries, using the provided less function within each type (mode).
+func sortEntriesWith(entries []*entry, less func(iEntry, jEntry *entry) bool) {
+	sort.SliceStable(entries, func(i, j int) bool {
 		iEntry := entries[i]
 		jEntry := entries[j]
 
@@ -122,14 +137,14 @@ func sortEntries(entries []*entry) {
 			if jEntry.hasMode(entryModeHidden) {
 				if iEntry.hasMode(entryModeDir) {
 					if jEntry.hasMode(entryModeDir) {
-						return iEntry.Name() < jEntry.Name()
+						return less(iEntry, jEntry)
 					}
 					return true
 				}
 				if jEntry.hasMode(entryModeDir) {
 					return false
 				}
-				return iEntry.Name() < jEntry.Name()
+				return less(iEntry, jEntry)
 			}
 			return false
 		}
@@ -139,7 +154,7 @@ func sortEntries(entries []*entry) {
 
 		if iEntry.hasMode(entryModeDir) {
 			if jEntry.hasMode(entryModeDir) {
-				return iEntry.Name() < jEntry.Name()
+				return less(iEntry, jEntry)
 			}
 			return true
 		}
@@ -147,6 +162,6 @@ func sortEntries(entries []*entry) {
 			return false
 		}
 
-		return iEntry.Name() < jEntry.Name()
+		return less(iEntry, jEntry)
 	})
 }
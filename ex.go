@@ -0,0 +1,639 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// exCommand is a named command run from the command line prompt.
+type exCommand struct {
+	name     string
+	args     string // Arguments displayed in the usage, with optional arguments in brackets.
+	help     string
+	complete func(m *model, word string) string // Completes a word of the arguments.
+	run      func(m *model, args string) tea.Cmd
+}
+
+// exCommands are the commands available from the command line prompt, including a command for
+// each key action so that every action is available without a key binding. The commands are
+// initialized in init because running a command can refer back to them.
+var exCommands []*exCommand
+
+func init() {
+	exCommands = []*exCommand{
+		{name: "cd", args: "[PATH]", help: "navigates to a directory, or the home directory", complete: completeDirPath, run: exCd},
+		exKeyCommand("select", "navigates into the directory or opens the file under the cursor", keySelect),
+		exKeyCommand("back", "navigates back to the previous directory", keyBack),
+		exKeyCommand("up", "moves the cursor up", keyUp),
+		exKeyCommand("down", "moves the cursor down", keyDown),
+		exKeyCommand("left", "moves the cursor left", keyLeft),
+		exKeyCommand("right", "moves the cursor right", keyRight),
+		exKeyCommand("first", "moves to the first entry", keyFirst, keyFirst),
+		exKeyCommand("last", "moves to the last entry", keyLast),
+		exKeyCommand("row-start", "moves to the first entry in the row", keyRowStart),
+		exKeyCommand("row-end", "moves to the last entry in the row", keyRowEnd),
+		exKeyCommand("top", "moves to the top of the column", keyScreenTop),
+		exKeyCommand("middle", "moves to the middle of the column", keyScreenMiddle),
+		exKeyCommand("bottom", "moves to the bottom of the column", keyScreenBottom),
+		exKeyCommand("column-prev", "moves to the top of the column or the previous column", keyColumnPrev),
+		exKeyCommand("column-next", "moves to the top of the next column", keyColumnNext),
+		exKeyCommand("page-down", "moves forward by a page", keyPageDown),
+		exKeyCommand("page-up", "moves back by a page", keyPageUp),
+		exKeyCommand("jump", "labels the displayed entries to jump to", keyJump),
+		{name: "goto", args: "[PATH]", help: "navigates to a directory or to the directory of a file", complete: completePath, run: exPrompt((*model).openGoToPrompt)},
+		{name: "search", args: "[TEXT]", help: "enters search mode, searching for the text", run: exSearch},
+
+		exKeyCommand("return", "returns the path(s) to the current entry or all marked entries", keyReturnSelected),
+		exKeyCommand("return-dir", "returns the path to the current directory", keyReturnDirectory),
+		exKeyCommand("yank", "copies the path(s) to the current entry or all marked entries", keyYankSelected),
+		exKeyCommand("yank-dir", "copies the path to the current directory", keyYankDirectory),
+
+		exKeyCommand("toggle-mark", "(un)marks the entry under the cursor", keyMark),
+		exKeyCommand("mark-all", "(un)marks all entries", keyMarkAll),
+		{name: "mark", args: "[PATTERN]", help: "marks entries with names matching a pattern", run: exPrompt(func(m *model) { m.openMarkPatternPrompt(true) })},
+		{name: "unmark", args: "[PATTERN]", help: "unmarks entries with names matching a pattern", run: exPrompt(func(m *model) { m.openMarkPatternPrompt(false) })},
+		{name: "mark-type", args: "TYPE", help: "marks all entries of a type (dir, exec, or symlink)", complete: completeMarkType, run: exMarkType},
+		exKeyCommand("invert-marks", "inverts the marks of all entries", keyInvertMarks),
+		exKeyCommand("visual", "enters visual mode", keyVisual),
+		exKeyCommand("basket", "opens the selection basket", keyBasket),
+		{name: "save-set", args: "[NAME]", help: "saves the marked entries as a named selection set", complete: completeSetName, run: exPrompt((*model).openSaveSetPrompt)},
+		{name: "mark-set", args: "NAME", help: "marks the entries of a saved selection set", complete: completeSetName, run: exMarkSet},
+		exKeyCommand("sets", "opens the saved selection sets", keySets),
+
+		{name: "mkdir", args: "PATH", help: "creates a directory", complete: completeDirPath, run: exMkdir},
+		{name: "copy", args: "[PATH]", help: "copies the entry under the cursor or all marked entries", complete: completePath, run: exPrompt((*model).openCopyPrompt)},
+		{name: "move", args: "[PATH]", help: "moves the entry under the cursor or all marked entries", complete: completePath, run: exPrompt((*model).openMovePrompt)},
+		{name: "shell", args: "[COMMAND]", help: "runs a shell command in the current directory", run: exPrompt((*model).openShellCommandPrompt)},
+		exKeyCommand("subshell", "starts a shell in the current directory", keySubshell),
+
+		{name: "set", args: "[OPTION]...", help: "sets, unsets (no prefix), toggles (! suffix), or shows (? suffix)\noptions, listing the enabled options without arguments", complete: completeOption, run: exSet},
+		{name: "sort", args: "[MODE]", help: "sorts by name, size, or time (mtime), or cycles the sort mode", complete: completeSortMode, run: exSort},
+		exKeyCommand("rescan", "rescans disk usage from the current directory", keyRescanDiskUsage),
+		exKeyCommand("help", "enters help mode", keyModeHelp),
+		{name: "debug", help: "enters debug mode (error details) for errors", run: exDebug},
+
+		exKeyCommand("pane", "switches focus to the other pane in dual pane mode", keySwitchPane),
+		{name: "tab-new", args: "[PATH]", help: "opens a new tab in a directory, or the current directory", complete: completeDirPath, run: exTabNew},
+		exKeyCommand("tab-close", "closes the current tab", keyCloseTab),
+		exKeyCommand("tab-next", "moves to the next tab", keyNextTab),
+		exKeyCommand("tab-prev", "moves to the previous tab", keyPrevTab),
+
+		exKeyCommand("quit", "quits the application with no return value", keyQuit),
+	}
+}
+
+// exKeyCommand constructs a command that runs the actions of pressing the provided keys.
+func exKeyCommand(name string, help string, keys ...key.Binding) *exCommand {
+	return &exCommand{
+		name: name,
+		help: help,
+		run: func(m *model, _ string) tea.Cmd {
+			cmds := []tea.Cmd{}
+			for _, k := range keys {
+				_, cmd := m.update(keyMsg(k))
+				cmds = append(cmds, cmd)
+			}
+			return tea.Batch(cmds...)
+		},
+	}
+}
+
+// exPrompt constructs a command that opens a prompt, submitting the arguments to it if provided.
+func exPrompt(open func(m *model)) func(m *model, args string) tea.Cmd {
+	return func(m *model, args string) tea.Cmd {
+		open(m)
+		if args == "" {
+			return nil
+		}
+		return m.submitPrompt(args)
+	}
+}
+
+func (m *model) openExPrompt() {
+	p := newPrompt("COMMAND", ":", (*model).runExCommand)
+	p.complete = (*model).completeEx
+	m.openPrompt(p)
+}
+
+// runExCommand runs a command line of a command name or unique prefix of a name followed by its
+// arguments. A number moves the cursor to the entry with that number.
+func (m *model) runExCommand(line string) tea.Cmd {
+	name, args, _ := strings.Cut(strings.TrimSpace(line), " ")
+	args = strings.TrimSpace(args)
+	if name == "" {
+		return nil
+	}
+
+	if n, err := strconv.Atoi(name); err == nil {
+		m.moveToIndex(n - 1)
+		m.saveCursor()
+		return nil
+	}
+
+	cmd, err := findExCommand(name)
+	if err != nil {
+		m.setError(err, err.Error())
+		return nil
+	}
+	return cmd.run(m, args)
+}
+
+// findExCommand returns the command with a name, or the only command with a name starting with it.
+func findExCommand(name string) (*exCommand, error) {
+	matches := []*exCommand{}
+	for _, cmd := range exCommands {
+		if cmd.name == name {
+			return cmd, nil
+		}
+		if strings.HasPrefix(cmd.name, name) {
+			matches = append(matches, cmd)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("unknown command: %s", name)
+	case 1:
+		return matches[0], nil
+	}
+	return nil, fmt.Errorf("ambiguous command: %s", name)
+}
+
+// completeEx completes the command name or the last word of its arguments.
+func (m *model) completeEx(line string) string {
+	name, args, found := strings.Cut(line, " ")
+	if !found {
+		names := make([]string, len(exCommands))
+		for i, cmd := range exCommands {
+			names[i] = cmd.name
+		}
+		return completeWord(name, names, " ")
+	}
+
+	cmd, err := findExCommand(name)
+	if err != nil || cmd.complete == nil {
+		return line
+	}
+	i := strings.LastIndex(args, " ") + 1
+	return name + " " + args[:i] + cmd.complete(m, args[i:])
+}
+
+// completeWord completes a word to the only candidate starting with it followed by a suffix, or to
+// the longest prefix shared by all candidates starting with it.
+func completeWord(word string, candidates []string, suffix string) string {
+	matches := matchingNames(word, candidates)
+	switch len(matches) {
+	case 0:
+		return word
+	case 1:
+		return matches[0] + suffix
+	}
+	return commonPrefix(matches)
+}
+
+// commonPrefix returns the longest prefix shared by all values.
+func commonPrefix(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	prefix := values[0]
+	for _, v := range values[1:] {
+		for !strings.HasPrefix(v, prefix) {
+			// Trim by rune to avoid splitting a multibyte character.
+			_, size := utf8.DecodeLastRuneInString(prefix)
+			prefix = prefix[:len(prefix)-size]
+		}
+	}
+	return prefix
+}
+
+func completePath(m *model, word string) string {
+	return m.completePath(word, false)
+}
+
+func completeDirPath(m *model, word string) string {
+	return m.completePath(word, true)
+}
+
+// completePath completes a path relative to the current directory, appending a separator to a
+// completed directory. Hidden entries are completed only when the name starts with a dot.
+func (m *model) completePath(word string, dirsOnly bool) string {
+	dir, base := filepath.Split(word)
+	expanded, err := m.expandPath(dir)
+	if err != nil {
+		return word
+	}
+	entries, err := os.ReadDir(expanded)
+	if err != nil {
+		return word
+	}
+
+	names := []string{}
+	dirs := map[string]bool{}
+	for _, e := range entries {
+		name := e.Name()
+		if strings.HasPrefix(name, ".") && !strings.HasPrefix(base, ".") {
+			continue
+		}
+		isDir := e.IsDir()
+		if !isDir && e.Type()&os.ModeSymlink != 0 {
+			if info, err := os.Stat(filepath.Join(expanded, name)); err == nil {
+				isDir = info.IsDir()
+			}
+		}
+		if dirsOnly && !isDir {
+			continue
+		}
+		names = append(names, name)
+		dirs[name] = isDir
+	}
+
+	matches := matchingNames(base, names)
+	if len(matches) == 1 && dirs[matches[0]] {
+		return dir + matches[0] + fileSeparator
+	}
+	if len(matches) > 1 && m.prompt != nil {
+		// List the candidates when the completion is ambiguous.
+		m.prompt.candidates = make([]string, len(matches))
+		for i, name := range matches {
+			m.prompt.candidates[i] = name
+			if dirs[name] {
+				m.prompt.candidates[i] += fileSeparator
+			}
+		}
+	}
+	return dir + completeWord(base, names, "")
+}
+
+// matchingNames returns the names starting with a prefix.
+func matchingNames(prefix string, names []string) []string {
+	matches := []string{}
+	for _, name := range names {
+		if strings.HasPrefix(name, prefix) {
+			matches = append(matches, name)
+		}
+	}
+	return matches
+}
+
+func completeMarkType(_ *model, word string) string {
+	return completeWord(word, exMarkTypeNames(), "")
+}
+
+func completeSortMode(_ *model, word string) string {
+	return completeWord(word, sortModeNames, "")
+}
+
+func completeSetName(_ *model, word string) string {
+	names, err := listSets()
+	if err != nil {
+		return word
+	}
+	return completeWord(word, names, "")
+}
+
+func completeOption(_ *model, word string) string {
+	prefix := ""
+	if strings.HasPrefix(word, "no") {
+		prefix, word = "no", word[2:]
+	}
+	names := make([]string, len(exOptions))
+	for i, opt := range exOptions {
+		names[i] = opt.name
+	}
+	return prefix + completeWord(word, names, "")
+}
+
+// expandPath returns the absolute path of a path relative to the current directory, expanding a
+// leading "~" or "~user" to a home directory and environment variables such as "$HOME".
+func (m *model) expandPath(path string) (string, error) {
+	if strings.HasPrefix(path, "~") {
+		name, rest, _ := strings.Cut(path[1:], fileSeparator)
+		if home, ok := homeDir(name); ok {
+			path = filepath.Join(home, rest)
+		}
+	}
+	path, err := expandEnv(path)
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(m.path, path)
+	}
+	return filepath.Clean(path), nil
+}
+
+// expandEnv replaces environment variables written as $NAME or ${NAME} with their values, returning
+// an error for a variable that is not set. A "$" that does not start a variable name is left
+// unchanged.
+func expandEnv(s string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		name, end := envName(s[i+1:])
+		if name == "" {
+			b.WriteByte(s[i])
+			continue
+		}
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		b.WriteString(value)
+		i += end
+	}
+	return b.String(), nil
+}
+
+// envName returns the variable name at the start of s, written as NAME or {NAME}, and the length
+// of the text it occupies. The name is empty if s does not start with a variable name.
+func envName(s string) (string, int) {
+	braced := strings.HasPrefix(s, "{")
+	start := 0
+	if braced {
+		start = 1
+	}
+
+	end := start
+	for end < len(s) && isEnvNameChar(s[end], end == start) {
+		end++
+	}
+	if end == start {
+		return "", 0
+	}
+	if braced {
+		if end == len(s) || s[end] != '}' {
+			return "", 0
+		}
+		return s[start:end], end + 1
+	}
+	return s[start:end], end
+}
+
+// isEnvNameChar returns whether a character can be part of an environment variable name, which
+// cannot start with a digit.
+func isEnvNameChar(c byte, first bool) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || (!first && '0' <= c && c <= '9')
+}
+
+// homeDir returns the home directory of a user, or of the current user when the name is empty.
+func homeDir(name string) (string, bool) {
+	if name == "" {
+		home, err := os.UserHomeDir()
+		return home, err == nil
+	}
+	u, err := user.Lookup(name)
+	if err != nil {
+		return "", false
+	}
+	return u.HomeDir, true
+}
+
+func exCd(m *model, args string) tea.Cmd {
+	path := "~"
+	if args != "" {
+		path = args
+	}
+	expanded, err := m.expandPath(path)
+	if err != nil {
+		m.setError(err, err.Error())
+		return nil
+	}
+	if err := m.changeDirectory(expanded); err != nil {
+		m.setError(err, err.Error())
+	}
+	return nil
+}
+
+// exDebug enters debug mode without pressing its key, which returns the current directory when no
+// error is shown.
+func exDebug(m *model, _ string) tea.Cmd {
+	if !m.modeError {
+		m.info = "no error to debug"
+		return nil
+	}
+	m.modeDebug = true
+	return nil
+}
+
+func exSearch(m *model, args string) tea.Cmd {
+	m.modeSearch = true
+	m.search.set(args)
+	return nil
+}
+
+// exMarkTypes maps the names of entry types to their modes.
+var exMarkTypes = map[string]entryMode{
+	"dir":     entryModeDir,
+	"exec":    entryModeExec,
+	"symlink": entryModeSymlink,
+}
+
+func exMarkTypeNames() []string {
+	names := []string{}
+	for name := range exMarkTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func exMarkType(m *model, args string) tea.Cmd {
+	mode, ok := exMarkTypes[args]
+	if !ok {
+		m.setError(
+			fmt.Errorf("invalid type %q, must be one of: %s", args, strings.Join(exMarkTypeNames(), ", ")),
+			"failed to mark entries",
+		)
+		return nil
+	}
+	m.markType(mode)
+	return nil
+}
+
+func exMarkSet(m *model, args string) tea.Cmd {
+	if err := m.markSet(args); err != nil {
+		m.setError(err, "failed to mark set")
+	}
+	return nil
+}
+
+func exMkdir(m *model, args string) tea.Cmd {
+	if args == "" {
+		m.setError(errors.New("no directory provided"), "failed to create directory")
+		return nil
+	}
+	path, err := m.expandPath(args)
+	if err != nil {
+		m.setError(err, "failed to create directory")
+		return nil
+	}
+	if err := os.MkdirAll(path, 0o755); err != nil {
+		m.setError(err, "failed to create directory")
+		return nil
+	}
+	m.relistPanes()
+	m.focusPath = path
+	return nil
+}
+
+func exSort(m *model, args string) tea.Cmd {
+	if args == "" {
+		_, cmd := m.update(keyMsg(keySort))
+		return cmd
+	}
+	mode, err := parseSortMode(args)
+	if err != nil {
+		m.setError(err, "failed to sort")
+		return nil
+	}
+	m.sortMode = mode
+	m.sort()
+	return nil
+}
+
+func exTabNew(m *model, args string) tea.Cmd {
+	path := m.path
+	if args != "" {
+		expanded, err := m.expandPath(args)
+		if err != nil {
+			m.setError(err, err.Error())
+			return nil
+		}
+		path = expanded
+	}
+	if err := m.openTab(); err != nil {
+		m.setError(err, err.Error())
+		return nil
+	}
+	if path != m.path {
+		if err := m.changeDirectory(path); err != nil {
+			m.setError(err, err.Error())
+		}
+	}
+	return nil
+}
+
+// exOption is a boolean option changed with the set command.
+type exOption struct {
+	name    string
+	value   func(m *model) *bool
+	changed func(m *model) error // Applies a change of the value.
+}
+
+var exOptions = []*exOption{
+	{name: "hidden", value: func(m *model) *bool { return &m.modeHidden }},
+	{name: "list", value: func(m *model) *bool { return &m.modeList }},
+	{name: "hide-gitignored", value: func(m *model) *bool { return &m.modeHideGitignore }},
+	{name: "dir-sizes", value: func(m *model) *bool { return &m.modeDirSizes }, changed: sortChanged},
+	{name: "disk-usage", value: func(m *model) *bool { return &m.modeDiskUsage }, changed: sortChanged},
+	{name: "tree", value: func(m *model) *bool { return &m.modeTree }, changed: (*model).relist},
+	{name: "columns", value: func(m *model) *bool { return &m.modeColumns }},
+	{name: "dual", value: func(m *model) *bool { return &m.modeDual }, changed: dualChanged},
+	{name: "follow", value: func(m *model) *bool { return &m.modeFollowSymlink }},
+	{name: "mark-order", value: func(m *model) *bool { return &m.modeMarkOrder }},
+	{name: "jump-select", value: func(m *model) *bool { return &m.modeJumpSelect }},
+}
+
+func sortChanged(m *model) error {
+	m.sort()
+	return nil
+}
+
+func dualChanged(m *model) error {
+	if m.modeDual {
+		return m.openDual()
+	}
+	return nil
+}
+
+// exSet sets, unsets, toggles, or shows options, following the conventions of vim:
+//
+//	option:   enables the option
+//	nooption: disables the option
+//	option!:  toggles the option
+//	option?:  shows the value of the option
+//
+// The enabled options are shown when no options are provided.
+func exSet(m *model, args string) tea.Cmd {
+	if args == "" {
+		enabled := []string{}
+		for _, opt := range exOptions {
+			if *opt.value(m) {
+				enabled = append(enabled, opt.name)
+			}
+		}
+		m.info = strings.Join(enabled, " ")
+		if len(enabled) == 0 {
+			m.info = "no options enabled"
+		}
+		return nil
+	}
+
+	for _, arg := range strings.Fields(args) {
+		if err := m.setOption(arg); err != nil {
+			m.setError(err, err.Error())
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *model) setOption(arg string) error {
+	name, value := arg, true
+	toggle, show := false, false
+	switch {
+	case strings.HasSuffix(arg, "!"):
+		name, toggle = strings.TrimSuffix(arg, "!"), true
+	case strings.HasSuffix(arg, "?"):
+		name, show = strings.TrimSuffix(arg, "?"), true
+	case strings.HasPrefix(arg, "no"):
+		name, value = strings.TrimPrefix(arg, "no"), false
+	}
+
+	var opt *exOption
+	for _, o := range exOptions {
+		if o.name == name {
+			opt = o
+			break
+		}
+	}
+	if opt == nil {
+		return fmt.Errorf("unknown option: %s", arg)
+	}
+
+	current := opt.value(m)
+	if show {
+		m.info = fmt.Sprintf("%s=%t", opt.name, *current)
+		return nil
+	}
+	if toggle {
+		value = !*current
+	}
+	if *current == value {
+		return nil
+	}
+	*current = value
+	if opt.changed != nil {
+		// Restore the previous value if it cannot be applied, such as when a second pane fails to
+		// list, so that the modes never disagree with the state they depend on.
+		if err := opt.changed(m); err != nil {
+			*current = !value
+			return err
+		}
+	}
+	return nil
+}
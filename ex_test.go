@@ -0,0 +1,116 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCommonPrefix(t *testing.T) {
+	tests := []struct {
+		values   []string
+		expected string
+	}{
+		{values: []string{}, expected: ""},
+		{values: []string{"sort"}, expected: "sort"},
+		{values: []string{"save-set", "select", "set"}, expected: "s"},
+		{values: []string{"tab-new", "tab-next"}, expected: "tab-ne"},
+		{values: []string{"mark", "unmark"}, expected: ""},
+		{values: []string{"café", "cafè"}, expected: "caf"},
+	}
+	for _, test := range tests {
+		if prefix := commonPrefix(test.values); prefix != test.expected {
+			t.Fatalf("expected prefix %q of %v, got %q", test.expected, test.values, prefix)
+		}
+	}
+}
+
+func TestCompleteWord(t *testing.T) {
+	candidates := []string{"name", "size", "time"}
+	tests := map[string]string{
+		"":  "",
+		"n": "name ",
+		"s": "size ",
+		"x": "x",
+	}
+	for word, expected := range tests {
+		if completed := completeWord(word, candidates, " "); completed != expected {
+			t.Fatalf("expected %q to complete to %q, got %q", word, expected, completed)
+		}
+	}
+}
+
+func TestFindExCommand(t *testing.T) {
+	tests := map[string]string{
+		"cd":     "cd",
+		"mark":   "mark",
+		"mkd":    "mkdir",
+		"quit":   "quit",
+		"q":      "quit",
+		"rescan": "rescan",
+	}
+	for name, expected := range tests {
+		cmd, err := findExCommand(name)
+		if err != nil {
+			t.Fatalf("unexpected error finding %q: %v", name, err)
+		}
+		if cmd.name != expected {
+			t.Fatalf("expected %q to find %q, got %q", name, expected, cmd.name)
+		}
+	}
+
+	for _, name := range []string{"ta", "s", "nonexistent"} {
+		if _, err := findExCommand(name); err == nil {
+			t.Fatalf("expected error finding %q", name)
+		}
+	}
+}
+
+func TestExpandPath(t *testing.T) {
+	t.Setenv("HOME", "/home/nav")
+	t.Setenv("NAV_TEST_DIR", "/opt/nav")
+	m := &model{tab: newTab("/tmp/a/b")}
+
+	tests := map[string]string{
+		"":                       "/tmp/a/b",
+		"c":                      "/tmp/a/b/c",
+		"../..":                  "/tmp",
+		"../../../..":            "/",
+		"/usr/bin/":              "/usr/bin",
+		"~":                      "/home/nav",
+		"~/src/../docs":          "/home/nav/docs",
+		"$NAV_TEST_DIR/bin":      "/opt/nav/bin",
+		"${NAV_TEST_DIR}/../lib": "/opt/lib",
+		"price$":                 "/tmp/a/b/price$",
+		"a$.b":                   "/tmp/a/b/a$.b",
+		"${NAV_TEST_DIR":         "/tmp/a/b/${NAV_TEST_DIR",
+		"$1":                     "/tmp/a/b/$1",
+	}
+	for path, expected := range tests {
+		expanded, err := m.expandPath(path)
+		if err != nil {
+			t.Fatalf("unexpected error expanding %q: %v", path, err)
+		}
+		if expanded != expected {
+			t.Fatalf("expected %q to expand to %q, got %q", path, expected, expanded)
+		}
+	}
+
+	for _, path := range []string{"$NAV_TEST_UNSET/bin", "${NAV_TEST_UNSET}"} {
+		if _, err := m.expandPath(path); err == nil {
+			t.Fatalf("expected error expanding unset variable in %q", path)
+		}
+	}
+}
+
+func TestSetOptionRollback(t *testing.T) {
+	m := newModel()
+	m.path = filepath.Join(t.TempDir(), "missing")
+
+	// The second pane cannot list the missing directory, so dual pane mode is not entered.
+	if err := m.setOption("dual"); err == nil {
+		t.Fatal("expected error opening a pane in a missing directory")
+	}
+	if m.modeDual || len(m.panes) != 1 {
+		t.Fatalf("expected a single pane outside of dual pane mode, got %d panes with dual %t", len(m.panes), m.modeDual)
+	}
+}
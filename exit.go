@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dkaslovsky/nav/internal/sanitize"
+)
+
+// returnDirectory sets the exit state to return the current directory and quits.
+func (m *model) returnDirectory() tea.Cmd {
+	m.setExit(sanitize.SanitizeOutputPath(m.path))
+	if m.modeSubshell {
+		fmt.Print(m.exitStr)
+	}
+	return tea.Quit
+}
+
+// returnPaths sets the exit state to return the provided paths and quits.
+func (m *model) returnPaths(paths []string) tea.Cmd {
+	m.exitPaths = paths
+	m.setExit(formatPaths(paths))
+	if m.modeSubshell {
+		fmt.Print(m.exitStr)
+	}
+	return tea.Quit
+}
+
+// returnSet prints the existing paths of a saved selection set without starting the program,
+// warning about paths that no longer exist.
+func (m *model) returnSet(setName string) error {
+	paths, err := loadSet(setName)
+	if err != nil {
+		return err
+	}
+	existing, stale := existingPaths(paths)
+	if len(stale) > 0 {
+		fmt.Fprintf(os.Stderr, "warning: %d path(s) in set %q no longer exist\n", len(stale), setName)
+	}
+
+	m.exitPaths = existing
+	fmt.Println(formatPaths(existing))
+	return m.writeChoiceFiles()
+}
+
+// formatPaths returns paths sanitized for output and separated by spaces.
+func formatPaths(paths []string) string {
+	sanitized := make([]string, len(paths))
+	for i, path := range paths {
+		sanitized[i] = sanitize.SanitizeOutputPath(path)
+	}
+	return strings.Join(sanitized, " ")
+}
+
+// writeChoiceFiles writes the final directory and returned paths to the files provided by the
+// --choosedir and --choosefiles flags. Paths are written unescaped with one path per line.
+func (m *model) writeChoiceFiles() error {
+	if m.chooseDirFile != "" {
+		err := os.WriteFile(m.chooseDirFile, []byte(m.path), 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", flagChooseDir, err)
+		}
+	}
+	if m.chooseFilesFile != "" && len(m.exitPaths) > 0 {
+		err := os.WriteFile(m.chooseFilesFile, []byte(strings.Join(m.exitPaths, "\n")+"\n"), 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", flagChooseFiles, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dkaslovsky/nav/internal/gitignore"
+	"github.com/dkaslovsky/nav/internal/gitstatus"
+)
+
+// gitignored returns whether an entry is ignored by git. The ignore rules are loaded when first
+// needed, as doing so runs git to locate the global excludes file.
+func (m *model) gitignored(ent *entry) bool {
+	if m.gitignore == nil {
+		m.gitignore = gitignore.NewCache()
+	}
+	return m.gitignore.Ignored(ent.path(), ent.hasMode(entryModeDir))
+}
+
+// gitStatusMsg is sent when the git status for a path has been loaded.
+type gitStatusMsg struct {
+	path   string
+	status *gitstatus.Status
+}
+
+// gitStatusCmd loads the git status for the current directory in the background when the
+// directory has been listed since the last request.
+func (m *model) gitStatusCmd() tea.Cmd {
+	if !m.modeGit || m.gitListed == m.listed {
+		return nil
+	}
+	m.gitListed = m.listed
+
+	path := m.path
+	return func() tea.Msg {
+		// Errors are not reported as directories outside of a work tree and systems without git
+		// are expected.
+		status, _ := gitstatus.Load(path)
+		return gitStatusMsg{path: path, status: status}
+	}
+}
+
+// gitStatus returns the git status of the work tree containing the current directory, or nil if
+// the current directory is not in a work tree or the status has not been loaded.
+func (m *model) gitStatus() *gitstatus.Status {
+	if !m.modeGit || m.git == nil || !m.git.Contains(m.path) {
+		return nil
+	}
+	return m.git
+}
+
+// gitBranchInfo returns the branch and its commits ahead of and behind its upstream.
+func gitBranchInfo(s *gitstatus.Status) string {
+	info := s.Branch
+	if s.HasUpstream && (s.Ahead > 0 || s.Behind > 0) {
+		info += fmt.Sprintf(" ↑%d ↓%d", s.Ahead, s.Behind)
+	}
+	return info
+}
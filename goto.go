@@ -0,0 +1,61 @@
+package main
+
+import (
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func (m *model) openGoToPrompt() {
+	p := newPrompt("GO TO", "go to: ", (*model).goTo)
+	p.complete = completePath
+	m.openPrompt(p)
+}
+
+// goTo navigates to a path, which can be relative to the current directory or start with "~",
+// "~user", or an environment variable. A file is shown in its directory with the cursor on it.
+func (m *model) goTo(value string) tea.Cmd {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	path, err := m.expandPath(value)
+	if err != nil {
+		m.setError(err, err.Error())
+		return nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		m.setError(err, "failed to go to "+path)
+		return nil
+	}
+	if info.IsDir() {
+		err = m.changeDirectory(path)
+	} else {
+		err = m.goToPath(path)
+	}
+	if err != nil {
+		m.setError(err, err.Error())
+	}
+	return nil
+}
+
+// candidatesView lists the candidates of an ambiguous prompt completion in place of the entries.
+func (m *model) candidatesView() string {
+	items := make([]statusBarItem, len(m.prompt.candidates))
+	for i, c := range m.prompt.candidates {
+		items[i] = statusBarItem(c)
+	}
+	gridNames, layout := gridMultiColumn(items, m.paneWidth(), m.viewHeight())
+
+	output := []string{m.locationBar()}
+	for row := 0; row < layout.rows && row < m.viewHeight(); row++ {
+		line := ""
+		for col := 0; col < layout.columns; col++ {
+			line += cursorRendererNormal.Render(gridNames[col][row])
+		}
+		output = append(output, line)
+	}
+	return strings.Join(output, "\n")
+}
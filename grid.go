@@ -22,8 +22,9 @@ func gridSingleColumn[T gridable](items []T, width int, _ int) ([][]string, grid
 }
 
 func gridMultiColumn[T gridable](items []T, width int, height int) ([][]string, gridLayout) {
-	// Target number of columns to use 1/3 of the available height.
-	tgtColumns := len(items) / (height / 3)
+	// Target number of columns to use 1/3 of the available height, which is at least one row in
+	// terminals too short to display a grid of three rows.
+	tgtColumns := len(items) / max(height/3, 1)
 	if tgtColumns < 1 {
 		tgtColumns = 1
 	}
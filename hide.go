@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// hiddenFileName is the name of the file listing entries to hide in its directory, a convention
+// used by GNOME and KDE file managers.
+const hiddenFileName = ".hidden"
+
+// readHiddenFile returns the names listed one per line in a directory's hidden file.
+func readHiddenFile(dir string) map[string]bool {
+	names := make(map[string]bool)
+
+	f, err := os.Open(filepath.Join(dir, hiddenFileName))
+	if err != nil {
+		return names
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if name := strings.TrimSpace(scanner.Text()); name != "" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// hideEntry reports whether an entry should be treated as hidden because its name matches a
+// configured hide pattern or is listed in its directory's hidden file.
+func (m *model) hideEntry(ent *entry, listed map[string]bool) bool {
+	if listed[ent.Name()] {
+		return true
+	}
+	for _, pattern := range m.hidePatterns {
+		if matched, _ := filepath.Match(pattern, ent.Name()); matched {
+			return true
+		}
+	}
+	return false
+}
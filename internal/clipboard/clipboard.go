@@ -0,0 +1,67 @@
+// Package clipboard copies text to the system clipboard.
+package clipboard
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+)
+
+// Sequence returns the OSC 52 escape sequence copying text, which is interpreted by the terminal
+// when written to it and therefore also works over SSH. The sequence is wrapped for tmux and
+// screen when running inside either.
+func Sequence(text string) string {
+	seq := osc52.New(text)
+	if os.Getenv("TMUX") != "" {
+		return seq.Tmux().String()
+	}
+	if strings.HasPrefix(os.Getenv("TERM"), "screen") {
+		return seq.Screen().String()
+	}
+	return seq.String()
+}
+
+// CopyLocal pipes text to a local clipboard command, if one is available, for terminals that do
+// not support OSC 52. Nothing is copied when running over SSH, where the local clipboard is not
+// the user's.
+func CopyLocal(text string) error {
+	if remote() {
+		return nil
+	}
+	cmd := localCommand()
+	if cmd == nil {
+		return nil
+	}
+	cmd.Stdin = strings.NewReader(text)
+	return cmd.Run()
+}
+
+// remote returns whether the program is running in an SSH session.
+func remote() bool {
+	return os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != ""
+}
+
+// localCommand returns a command that copies its standard input to the clipboard of the local
+// display, or nil if none is available.
+func localCommand() *exec.Cmd {
+	candidates := [][]string{}
+	if runtime.GOOS == "darwin" {
+		candidates = append(candidates, []string{"pbcopy"})
+	}
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		candidates = append(candidates, []string{"wl-copy"})
+	}
+	if os.Getenv("DISPLAY") != "" {
+		candidates = append(candidates, []string{"xclip", "-selection", "clipboard"})
+	}
+
+	for _, c := range candidates {
+		if _, err := exec.LookPath(c[0]); err == nil {
+			return exec.Command(c[0], c[1:]...)
+		}
+	}
+	return nil
+}
@@ -42,6 +42,15 @@ func GroupName(info fs.FileInfo) (string, error) {
 	return grp.Name, nil
 }
 
+// HardLink returns the device and inode identifying a file with more than one hard link.
+func HardLink(info fs.FileInfo) (uint64, uint64, bool) {
+	stat, ok := stat(info)
+	if !ok || stat.Nlink <= 1 {
+		return 0, 0, false
+	}
+	return uint64(stat.Dev), uint64(stat.Ino), true
+}
+
 func stat(info fs.FileInfo) (*syscall.Stat_t, bool) {
 	stat, ok := info.Sys().(*syscall.Stat_t)
 	return stat, ok
@@ -21,3 +21,8 @@ func UserName(info fs.FileInfo) (string, error) {
 func GroupName(info fs.FileInfo) (string, error) {
 	return "", ErrNoGroup
 }
+
+// HardLink returns the device and inode identifying a file with more than one hard link.
+func HardLink(info fs.FileInfo) (uint64, uint64, bool) {
+	return 0, 0, false
+}
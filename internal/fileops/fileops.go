@@ -0,0 +1,121 @@
+// Package fileops copies and moves files and directories.
+package fileops
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// Copy copies the file, directory, or symlink at src to dst, which must not exist. Directories are
+// copied recursively and symlinks are copied as symlinks.
+func Copy(src string, dst string) error {
+	info, err := os.Lstat(src)
+	if err != nil {
+		return err
+	}
+	if err := checkDestination(src, dst, info); err != nil {
+		return err
+	}
+	return copyPath(src, dst, info)
+}
+
+// Move moves the file, directory, or symlink at src to dst, which must not exist. Moves across file
+// systems copy src and then remove it.
+func Move(src string, dst string) error {
+	info, err := os.Lstat(src)
+	if err != nil {
+		return err
+	}
+	if err := checkDestination(src, dst, info); err != nil {
+		return err
+	}
+
+	err = os.Rename(src, dst)
+	if err == nil || !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+	if err := copyPath(src, dst, info); err != nil {
+		return err
+	}
+	return os.RemoveAll(src)
+}
+
+func checkDestination(src string, dst string, info fs.FileInfo) error {
+	if _, err := os.Lstat(dst); err == nil {
+		return fmt.Errorf("%s already exists", dst)
+	}
+	if info.IsDir() && within(dst, src) {
+		return fmt.Errorf("cannot copy or move %s into itself", src)
+	}
+	return nil
+}
+
+func copyPath(src string, dst string, info fs.FileInfo) error {
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		target, err := os.Readlink(src)
+		if err != nil {
+			return err
+		}
+		return os.Symlink(target, dst)
+
+	case info.IsDir():
+		entries, err := os.ReadDir(src)
+		if err != nil {
+			return err
+		}
+		if err := os.Mkdir(dst, info.Mode().Perm()); err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			entryInfo, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			err = copyPath(filepath.Join(src, entry.Name()), filepath.Join(dst, entry.Name()), entryInfo)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case info.Mode().IsRegular():
+		return copyFile(src, dst, info.Mode().Perm())
+
+	default:
+		return fmt.Errorf("cannot copy special file %s", src)
+	}
+}
+
+func copyFile(src string, dst string, perm fs.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// within reports whether path is dir or is contained in dir.
+func within(path string, dir string) bool {
+	rel, err := filepath.Rel(filepath.Clean(dir), filepath.Clean(path))
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
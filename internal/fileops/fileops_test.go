@@ -0,0 +1,106 @@
+package fileops
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCopy(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src")
+	mustWrite(t, filepath.Join(src, "a"), "a")
+	mustWrite(t, filepath.Join(src, "sub", "b"), "b")
+	if err := os.Symlink("a", filepath.Join(src, "link")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	dst := filepath.Join(dir, "dst")
+	if err := Copy(src, dst); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertContent(t, filepath.Join(dst, "a"), "a")
+	assertContent(t, filepath.Join(dst, "sub", "b"), "b")
+	if target, err := os.Readlink(filepath.Join(dst, "link")); err != nil || target != "a" {
+		t.Fatalf("expected symlink to a, got %q, %v", target, err)
+	}
+	assertContent(t, filepath.Join(src, "a"), "a")
+}
+
+func TestCopyErrors(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src")
+	mustWrite(t, filepath.Join(src, "a"), "a")
+	mustWrite(t, filepath.Join(dir, "existing"), "")
+
+	tests := map[string]string{
+		"existing destination": filepath.Join(dir, "existing"),
+		"into itself":          filepath.Join(src, "sub"),
+	}
+	for name, dst := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := Copy(src, dst); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestMove(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src")
+	mustWrite(t, filepath.Join(src, "a"), "a")
+
+	dst := filepath.Join(dir, "dst")
+	if err := Move(src, dst); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertContent(t, filepath.Join(dst, "a"), "a")
+	if _, err := os.Lstat(src); !os.IsNotExist(err) {
+		t.Fatalf("expected %s to be removed, got %v", src, err)
+	}
+}
+
+func TestWithin(t *testing.T) {
+	tests := map[string]struct {
+		path     string
+		dir      string
+		expected bool
+	}{
+		"same":      {path: "/a/b", dir: "/a/b", expected: true},
+		"child":     {path: "/a/b/c", dir: "/a/b", expected: true},
+		"parent":    {path: "/a", dir: "/a/b", expected: false},
+		"sibling":   {path: "/a/bc", dir: "/a/b", expected: false},
+		"dot names": {path: "/a/..b", dir: "/a", expected: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := within(filepath.FromSlash(test.path), filepath.FromSlash(test.dir)); got != test.expected {
+				t.Fatalf("expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func mustWrite(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func assertContent(t *testing.T, path string, expected string) {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+	if string(content) != expected {
+		t.Fatalf("expected %q in %s, got %q", expected, path, string(content))
+	}
+}
@@ -0,0 +1,303 @@
+// Package gitignore evaluates whether paths are ignored by the rules of the git work tree
+// containing them.
+package gitignore
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// pattern is a single rule parsed from an ignore file.
+type pattern struct {
+	re       *regexp.Regexp
+	base     string // Slash separated directory relative to the work tree root that the rule applies to.
+	negate   bool
+	dirOnly  bool
+	anchored bool // Matched against the full path relative to base rather than the name.
+}
+
+func parsePattern(line string, base string) (*pattern, bool) {
+	// Trailing spaces are ignored unless escaped.
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, "\\ ") {
+		line = line[:len(line)-1]
+	}
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil, false
+	}
+
+	p := &pattern{base: base}
+	if strings.HasPrefix(line, "!") {
+		p.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		p.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if strings.Contains(line, "/") {
+		p.anchored = true
+		line = strings.TrimPrefix(line, "/")
+	}
+	if line == "" {
+		return nil, false
+	}
+
+	re, err := regexp.Compile("^" + globToRegexp(line) + "$")
+	if err != nil {
+		return nil, false
+	}
+	p.re = re
+	return p, true
+}
+
+// globToRegexp translates a gitignore glob into a regular expression.
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	for i := 0; i < len(glob); i++ {
+		ch := glob[i]
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			// Leading or inner "**/" matches zero or more directories.
+			if i == 0 || glob[i-1] == '/' {
+				b.WriteString("(?:.*/)?")
+				i += 2
+			} else {
+				b.WriteString("[^/]*")
+				i++
+			}
+		case strings.HasPrefix(glob[i:], "**") && i+2 == len(glob) && (i == 0 || glob[i-1] == '/'):
+			// Trailing "**" matches everything inside.
+			b.WriteString(".*")
+			i++
+		case ch == '*':
+			b.WriteString("[^/]*")
+		case ch == '?':
+			b.WriteString("[^/]")
+		case ch == '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case ch == '\\' && i+1 < len(glob):
+			i++
+			b.WriteString(regexp.QuoteMeta(string(glob[i])))
+		default:
+			b.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+	return b.String()
+}
+
+// match reports whether the pattern applies to a slash separated path relative to the work tree
+// root.
+func (p *pattern) match(path string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+	if p.base != "" {
+		if !strings.HasPrefix(path, p.base+"/") {
+			return false
+		}
+		path = path[len(p.base)+1:]
+	}
+	if !p.anchored {
+		path = path[strings.LastIndex(path, "/")+1:]
+	}
+	return p.re.MatchString(path)
+}
+
+func readPatterns(file string, base string) []*pattern {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	patterns := []*pattern{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if p, ok := parsePattern(scanner.Text(), base); ok {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
+// matcher contains the patterns applicable to a directory ordered from lowest to highest
+// precedence.
+type matcher struct {
+	patterns []*pattern
+}
+
+// ignored reports whether a path is ignored, determined by the last matching pattern.
+func (m *matcher) ignored(path string, isDir bool) bool {
+	for i := len(m.patterns) - 1; i >= 0; i-- {
+		p := m.patterns[i]
+		if p.match(path, isDir) {
+			return !p.negate
+		}
+	}
+	return false
+}
+
+// repo evaluates ignore rules for a single work tree, caching the matchers and results for each
+// directory.
+type repo struct {
+	root        string
+	matchers    map[string]*matcher // Map slash separated relative directory to its matcher.
+	ignoredDirs map[string]bool     // Map slash separated relative directory to its ignored state.
+}
+
+func newRepo(root string, globalExcludes string) *repo {
+	patterns := readPatterns(globalExcludes, "")
+	patterns = append(patterns, readPatterns(filepath.Join(gitDir(root), "info", "exclude"), "")...)
+	patterns = append(patterns, readPatterns(filepath.Join(root, ".gitignore"), "")...)
+
+	return &repo{
+		root:        root,
+		matchers:    map[string]*matcher{"": {patterns: patterns}},
+		ignoredDirs: map[string]bool{"": false},
+	}
+}
+
+// matcher returns the matcher for a directory, including the .gitignore files of the directory and
+// all of its parents.
+func (r *repo) matcher(dir string) *matcher {
+	if m, ok := r.matchers[dir]; ok {
+		return m
+	}
+	parent := r.matcher(parentDir(dir))
+	patterns := append([]*pattern{}, parent.patterns...)
+	patterns = append(patterns, readPatterns(filepath.Join(r.root, filepath.FromSlash(dir), ".gitignore"), dir)...)
+
+	m := &matcher{patterns: patterns}
+	r.matchers[dir] = m
+	return m
+}
+
+// dirIgnored reports whether a directory or any of its parents is ignored.
+func (r *repo) dirIgnored(dir string) bool {
+	if ignored, ok := r.ignoredDirs[dir]; ok {
+		return ignored
+	}
+	parent := parentDir(dir)
+	ignored := r.dirIgnored(parent) || r.matcher(parent).ignored(dir, true)
+	r.ignoredDirs[dir] = ignored
+	return ignored
+}
+
+func (r *repo) ignored(path string, isDir bool) bool {
+	dir := parentDir(path)
+	return r.dirIgnored(dir) || r.matcher(dir).ignored(path, isDir)
+}
+
+// Cache evaluates whether paths are ignored by git, caching the rules for each directory.
+type Cache struct {
+	globalExcludes string
+	roots          map[string]string // Map directory to the root of its work tree or "" if none.
+	repos          map[string]*repo  // Map work tree root to its rules.
+}
+
+func NewCache() *Cache {
+	return newCache(globalExcludesFile())
+}
+
+func newCache(globalExcludes string) *Cache {
+	return &Cache{
+		globalExcludes: globalExcludes,
+		roots:          make(map[string]string),
+		repos:          make(map[string]*repo),
+	}
+}
+
+// Ignored reports whether the file or directory at an absolute path is ignored by the rules of the
+// work tree containing it. Paths outside of a work tree are never ignored.
+func (c *Cache) Ignored(path string, isDir bool) bool {
+	root := c.root(filepath.Dir(path))
+	if root == "" {
+		return false
+	}
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == "." || rel == ".git" {
+		return false
+	}
+
+	r, ok := c.repos[root]
+	if !ok {
+		r = newRepo(root, c.globalExcludes)
+		c.repos[root] = r
+	}
+	return r.ignored(filepath.ToSlash(rel), isDir)
+}
+
+// root returns the root of the work tree containing dir.
+func (c *Cache) root(dir string) string {
+	if root, ok := c.roots[dir]; ok {
+		return root
+	}
+
+	root := ""
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+		root = dir
+	} else if parent := filepath.Dir(dir); parent != dir {
+		root = c.root(parent)
+	}
+	c.roots[dir] = root
+	return root
+}
+
+// gitDir returns the git directory of a work tree, following the gitdir file used by linked work
+// trees and submodules.
+func gitDir(root string) string {
+	dotGit := filepath.Join(root, ".git")
+	data, err := os.ReadFile(dotGit)
+	if err != nil {
+		return dotGit
+	}
+	dir, found := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir: ")
+	if !found {
+		return dotGit
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(root, dir)
+	}
+	return dir
+}
+
+// globalExcludesFile returns the path of the user's global excludes file from the core.excludesFile
+// setting or its default location.
+func globalExcludesFile() string {
+	if out, err := exec.Command("git", "config", "--path", "--get", "core.excludesFile").Output(); err == nil {
+		if file := strings.TrimSpace(string(out)); file != "" {
+			return file
+		}
+	}
+	if configHome := os.Getenv("XDG_CONFIG_HOME"); configHome != "" {
+		return filepath.Join(configHome, "git", "ignore")
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, ".config", "git", "ignore")
+	}
+	return ""
+}
+
+func parentDir(path string) string {
+	idx := strings.LastIndex(path, "/")
+	if idx < 0 {
+		return ""
+	}
+	return path[:idx]
+}
@@ -0,0 +1,100 @@
+package gitignore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPatternMatch(t *testing.T) {
+	tests := map[string]struct {
+		pattern string
+		base    string
+		path    string
+		isDir   bool
+		want    bool
+	}{
+		"name_at_root":                {pattern: "*.pyc", path: "a.pyc", want: true},
+		"name_at_any_depth":           {pattern: "*.pyc", path: "a/b/c.pyc", want: true},
+		"name_no_match":               {pattern: "*.pyc", path: "a/b/c.py", want: false},
+		"dir_only_matches_dir":        {pattern: "bin/", path: "cmd/bin", isDir: true, want: true},
+		"dir_only_skips_file":         {pattern: "bin/", path: "cmd/bin", want: false},
+		"leading_slash_anchors":       {pattern: "/bin", path: "cmd/bin", isDir: true, want: false},
+		"leading_slash_matches_root":  {pattern: "/bin", path: "bin", isDir: true, want: true},
+		"inner_slash_anchors":         {pattern: "doc/*.txt", path: "doc/a.txt", want: true},
+		"inner_slash_single_level":    {pattern: "doc/*.txt", path: "doc/sub/a.txt", want: false},
+		"leading_double_star":         {pattern: "**/foo", path: "a/b/foo", want: true},
+		"leading_double_star_root":    {pattern: "**/foo", path: "foo", want: true},
+		"inner_double_star":           {pattern: "a/**/b", path: "a/x/y/b", want: true},
+		"inner_double_star_zero_dirs": {pattern: "a/**/b", path: "a/b", want: true},
+		"trailing_double_star":        {pattern: "a/**", path: "a/x/y", want: true},
+		"trailing_double_star_self":   {pattern: "a/**", path: "a", isDir: true, want: false},
+		"character_class":             {pattern: "file[0-9].txt", path: "file1.txt", want: true},
+		"negated_character_class":     {pattern: "file[!0-9].txt", path: "file1.txt", want: false},
+		"escaped_character":           {pattern: `\#notes`, path: "#notes", want: true},
+		"base_directory":              {pattern: "*.log", base: "sub", path: "sub/x/a.log", want: true},
+		"outside_base_directory":      {pattern: "*.log", base: "sub", path: "other/a.log", want: false},
+		"anchored_to_base_directory":  {pattern: "/out", base: "sub", path: "sub/out", isDir: true, want: true},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(tt *testing.T) {
+			p, ok := parsePattern(test.pattern, test.base)
+			if !ok {
+				tt.Fatalf("failed to parse pattern %q", test.pattern)
+			}
+			if got := p.match(test.path, test.isDir); got != test.want {
+				tt.Fatalf("expected %t for pattern %q and path %q", test.want, test.pattern, test.path)
+			}
+		})
+	}
+}
+
+func TestCacheIgnored(t *testing.T) {
+	root := t.TempDir()
+	writeFile := func(path string, contents string) {
+		path = filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeFile("global", "*.swp\n")
+	writeFile(".git/info/exclude", "scratch/\n")
+	writeFile(".gitignore", "*.log\nbuild/\n!keep.log\n")
+	writeFile("sub/.gitignore", "!debug.log\n*.tmp\n")
+
+	cache := newCache(filepath.Join(root, "global"))
+
+	tests := map[string]struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		"global_excludes":          {path: "a.swp", want: true},
+		"info_exclude":             {path: "scratch", isDir: true, want: true},
+		"root_gitignore":           {path: "a.log", want: true},
+		"root_negation":            {path: "keep.log", want: false},
+		"nested_negation":          {path: "sub/debug.log", want: false},
+		"root_rule_in_nested":      {path: "sub/other.log", want: true},
+		"nested_rule":              {path: "sub/a.tmp", want: true},
+		"nested_rule_not_at_root":  {path: "a.tmp", want: false},
+		"inside_ignored_directory": {path: "build/keep.log", want: true},
+		"not_ignored":              {path: "main.go", want: false},
+		"git_directory":            {path: ".git", isDir: true, want: false},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(tt *testing.T) {
+			got := cache.Ignored(filepath.Join(root, filepath.FromSlash(test.path)), test.isDir)
+			if got != test.want {
+				tt.Fatalf("expected %t for path %q", test.want, test.path)
+			}
+		})
+	}
+}
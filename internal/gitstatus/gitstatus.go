@@ -0,0 +1,214 @@
+package gitstatus
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var ErrNotWorkTree = errors.New("not inside a git work tree")
+
+// Flags describe the status of a path in a git work tree.
+type Flags uint8
+
+const (
+	Modified Flags = 1 << iota
+	Staged
+	Untracked
+	Ignored
+	Conflicted
+)
+
+// aggregateFlags are the flags of paths within a directory that are applied to the directory.
+const aggregateFlags = Modified | Staged | Untracked | Conflicted
+
+func (f Flags) Has(tgt Flags) bool {
+	return f&tgt == tgt
+}
+
+// Markers returns a short string of characters representing the flags.
+func (f Flags) Markers() string {
+	markers := ""
+	if f.Has(Conflicted) {
+		markers += "U"
+	}
+	if f.Has(Staged) {
+		markers += "+"
+	}
+	if f.Has(Modified) {
+		markers += "M"
+	}
+	if f.Has(Untracked) {
+		markers += "?"
+	}
+	if f.Has(Ignored) {
+		markers += "!"
+	}
+	return markers
+}
+
+// Status is the status of a git work tree.
+type Status struct {
+	Root        string // Absolute path of the root of the work tree.
+	Branch      string
+	HasUpstream bool
+	Ahead       int
+	Behind      int
+
+	paths map[string]Flags // Map slash separated path relative to the root to its flags.
+	dirs  map[string]Flags // Map slash separated directory relative to the root to aggregate flags.
+}
+
+// Load runs git to determine the status of the work tree containing dir.
+func Load(dir string) (*Status, error) {
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return nil, ErrNotWorkTree
+	}
+	root := strings.TrimSpace(string(out))
+
+	out, err = exec.Command("git", "-C", root, "status", "--porcelain=v2", "--branch", "--ignored", "-z").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run git status: %w", err)
+	}
+	return Parse(root, out)
+}
+
+// Parse constructs a Status from the output of "git status --porcelain=v2 --branch -z" run from
+// the root of a work tree.
+func Parse(root string, out []byte) (*Status, error) {
+	s := &Status{
+		Root:  filepath.Clean(root),
+		paths: make(map[string]Flags),
+		dirs:  make(map[string]Flags),
+	}
+
+	records := bytes.Split(out, []byte{0})
+	for i := 0; i < len(records); i++ {
+		record := string(records[i])
+		if record == "" {
+			continue
+		}
+
+		switch record[0] {
+		case '#':
+			s.parseHeader(record)
+		case '1':
+			// 1 <XY> <sub> <mH> <mI> <mW> <hH> <hI> <path>
+			fields := strings.SplitN(record, " ", 9)
+			if len(fields) != 9 {
+				return nil, fmt.Errorf("malformed status record: %q", record)
+			}
+			s.add(fields[8], changeFlags(fields[1]))
+		case '2':
+			// 2 <XY> <sub> <mH> <mI> <mW> <hH> <hI> <X><score> <path> followed by the original path.
+			fields := strings.SplitN(record, " ", 10)
+			if len(fields) != 10 {
+				return nil, fmt.Errorf("malformed status record: %q", record)
+			}
+			s.add(fields[9], changeFlags(fields[1]))
+			i++
+		case 'u':
+			// u <XY> <sub> <m1> <m2> <m3> <mW> <h1> <h2> <h3> <path>
+			fields := strings.SplitN(record, " ", 11)
+			if len(fields) != 11 {
+				return nil, fmt.Errorf("malformed status record: %q", record)
+			}
+			s.add(fields[10], Conflicted)
+		case '?':
+			s.add(strings.TrimPrefix(record, "? "), Untracked)
+		case '!':
+			s.add(strings.TrimPrefix(record, "! "), Ignored)
+		}
+	}
+	return s, nil
+}
+
+func (s *Status) parseHeader(record string) {
+	key, value, _ := strings.Cut(strings.TrimPrefix(record, "# "), " ")
+	switch key {
+	case "branch.head":
+		s.Branch = value
+	case "branch.upstream":
+		s.HasUpstream = true
+	case "branch.ab":
+		ahead, behind, _ := strings.Cut(value, " ")
+		s.Ahead, _ = strconv.Atoi(strings.TrimPrefix(ahead, "+"))
+		s.Behind, _ = strconv.Atoi(strings.TrimPrefix(behind, "-"))
+	}
+}
+
+func changeFlags(xy string) Flags {
+	flags := Flags(0)
+	if len(xy) != 2 {
+		return flags
+	}
+	if xy[0] != '.' {
+		flags |= Staged
+	}
+	if xy[1] != '.' {
+		flags |= Modified
+	}
+	return flags
+}
+
+// add records the flags for a path and aggregates them into all parent directories. Untracked
+// and ignored directories are reported by git with a trailing separator.
+func (s *Status) add(path string, flags Flags) {
+	path = strings.TrimSuffix(path, "/")
+	s.paths[path] |= flags
+
+	if flags&aggregateFlags == 0 {
+		return
+	}
+	for dir := parentDir(path); dir != ""; dir = parentDir(dir) {
+		s.dirs[dir] |= flags & aggregateFlags
+	}
+}
+
+// Contains reports whether path is inside the work tree.
+func (s *Status) Contains(path string) bool {
+	_, ok := s.relPath(path)
+	return ok
+}
+
+// Lookup returns the flags for an absolute path. Directories include the aggregate flags of the
+// paths they contain, and paths inside untracked or ignored directories inherit those flags.
+func (s *Status) Lookup(path string, isDir bool) Flags {
+	rel, ok := s.relPath(path)
+	if !ok || rel == "" {
+		return 0
+	}
+
+	flags := s.paths[rel]
+	if isDir {
+		flags |= s.dirs[rel]
+	}
+	for dir := parentDir(rel); dir != ""; dir = parentDir(dir) {
+		flags |= s.paths[dir] & (Untracked | Ignored)
+	}
+	return flags
+}
+
+func (s *Status) relPath(path string) (string, bool) {
+	rel, err := filepath.Rel(s.Root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	if rel == "." {
+		return "", true
+	}
+	return filepath.ToSlash(rel), true
+}
+
+func parentDir(path string) string {
+	idx := strings.LastIndex(path, "/")
+	if idx < 0 {
+		return ""
+	}
+	return path[:idx]
+}
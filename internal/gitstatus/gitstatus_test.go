@@ -0,0 +1,100 @@
+package gitstatus
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testStatusOutput(records ...string) []byte {
+	return []byte(strings.Join(records, "\x00") + "\x00")
+}
+
+func TestParse(t *testing.T) {
+	root := filepath.FromSlash("/repo")
+	out := testStatusOutput(
+		"# branch.oid 0123456789abcdef",
+		"# branch.head main",
+		"# branch.upstream origin/main",
+		"# branch.ab +2 -1",
+		"1 .M N... 100644 100644 100644 abc abc src/main.go",
+		"1 A. N... 000000 100644 100644 000 abc src/pkg/new file.go",
+		"2 R. N... 100644 100644 100644 abc abc R100 docs/renamed.md",
+		"docs/original.md",
+		"u UU N... 100644 100644 100644 100644 abc abc abc conflict.txt",
+		"? notes/",
+		"! bin/",
+	)
+
+	s, err := Parse(root, out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if s.Branch != "main" || !s.HasUpstream || s.Ahead != 2 || s.Behind != 1 {
+		t.Fatalf("incorrect branch information: %+v", s)
+	}
+
+	tests := map[string]struct {
+		path  string
+		isDir bool
+		want  Flags
+	}{
+		"modified_file": {
+			path: "src/main.go",
+			want: Modified,
+		},
+		"staged_file_with_space": {
+			path: "src/pkg/new file.go",
+			want: Staged,
+		},
+		"renamed_file": {
+			path: "docs/renamed.md",
+			want: Staged,
+		},
+		"original_of_renamed_file": {
+			path: "docs/original.md",
+			want: 0,
+		},
+		"conflicted_file": {
+			path: "conflict.txt",
+			want: Conflicted,
+		},
+		"aggregate_directory": {
+			path:  "src",
+			isDir: true,
+			want:  Modified | Staged,
+		},
+		"untracked_directory": {
+			path:  "notes",
+			isDir: true,
+			want:  Untracked,
+		},
+		"file_in_untracked_directory": {
+			path: "notes/todo.txt",
+			want: Untracked,
+		},
+		"file_in_ignored_directory": {
+			path: "bin/nav",
+			want: Ignored,
+		},
+		"clean_file": {
+			path: "README.md",
+			want: 0,
+		},
+		"outside_work_tree": {
+			path: "../other/src/main.go",
+			want: 0,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(tt *testing.T) {
+			got := s.Lookup(filepath.Join(root, filepath.FromSlash(test.path)), test.isDir)
+			if got != test.want {
+				tt.Fatalf("expected flags %q, got %q", test.want.Markers(), got.Markers())
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+//go:build !windows
+
+package shell
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Command constructs a command that runs a shell command line with args appended as quoted
+// positional arguments.
+func Command(command string, args ...string) *exec.Cmd {
+	if len(args) > 0 {
+		command += ` "$@"`
+	}
+	return exec.Command("sh", append([]string{"-c", command, "sh"}, args...)...)
+}
+
+// CommandWithPause constructs a command that runs a shell command line and waits for the enter
+// key to be pressed before exiting so that its output remains visible.
+func CommandWithPause(command string) *exec.Cmd {
+	return Command(command + "\nprintf '\\nPress enter to continue' >&2; read _")
+}
+
+// Quote quotes a string for use as a single word in a shell command line.
+func Quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// Interactive returns the user's interactive shell.
+func Interactive() string {
+	if sh := os.Getenv("SHELL"); sh != "" {
+		return sh
+	}
+	return "sh"
+}
+
+// SystemOpener returns the name of the platform's default file opener.
+func SystemOpener() string {
+	if runtime.GOOS == "darwin" {
+		return "open"
+	}
+	return "xdg-open"
+}
@@ -0,0 +1,43 @@
+//go:build windows
+
+package shell
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Command constructs a command that runs a shell command line with args appended as quoted
+// positional arguments.
+func Command(command string, args ...string) *exec.Cmd {
+	quoted := []string{command}
+	for _, arg := range args {
+		quoted = append(quoted, Quote(arg))
+	}
+	return exec.Command("cmd", "/C", strings.Join(quoted, " "))
+}
+
+// CommandWithPause constructs a command that runs a shell command line and waits for a key to be
+// pressed before exiting so that its output remains visible.
+func CommandWithPause(command string) *exec.Cmd {
+	return Command(command + " & pause")
+}
+
+// Quote quotes a string for use as a single word in a shell command line.
+func Quote(s string) string {
+	return `"` + s + `"`
+}
+
+// Interactive returns the user's interactive shell.
+func Interactive() string {
+	if sh := os.Getenv("COMSPEC"); sh != "" {
+		return sh
+	}
+	return "cmd"
+}
+
+// SystemOpener returns the name of the platform's default file opener.
+func SystemOpener() string {
+	return "start \"\""
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// jumpLabelChars are the characters used for jump labels, ordered with the home row first.
+const jumpLabelChars = "asdfghjklqwertyuiopzxcvbnm"
+
+// jumpLabelMaxLen is the maximum length of a jump label. Labels replace the cursor column and the
+// space following it, so a label can be up to the column separator length less one to leave a
+// space before the entry name without changing the width of the grid columns.
+const jumpLabelMaxLen = columnSeparatorLen - 1
+
+// startJump enters jump mode, labeling each displayed entry.
+func (m *model) startJump() {
+	m.modeJump = true
+	m.jumpInput = ""
+}
+
+// jumpLabelLen returns the length of the labels for a number of entries. All labels have the same
+// length so that no label is a prefix of another.
+func jumpLabelLen(n int) int {
+	length := 1
+	for capacity := len(jumpLabelChars); capacity < n && length < jumpLabelMaxLen; capacity *= len(jumpLabelChars) {
+		length++
+	}
+	return length
+}
+
+// jumpLabel returns the label of a display index for labels of a given length, or false if the
+// index cannot be labeled.
+func jumpLabel(dispIdx int, length int) (string, bool) {
+	label := make([]byte, length)
+	for i := length - 1; i >= 0; i-- {
+		label[i] = jumpLabelChars[dispIdx%len(jumpLabelChars)]
+		dispIdx /= len(jumpLabelChars)
+	}
+	return string(label), dispIdx == 0
+}
+
+// jumpIndex returns the display index of a label.
+func jumpIndex(label string) (int, bool) {
+	dispIdx := 0
+	for _, r := range label {
+		i := strings.IndexRune(jumpLabelChars, r)
+		if i < 0 {
+			return 0, false
+		}
+		dispIdx = dispIdx*len(jumpLabelChars) + i
+	}
+	return dispIdx, true
+}
+
+// displayedJumpLabel returns the label of a display index when it matches the characters typed in
+// jump mode.
+func (m *model) displayedJumpLabel(dispIdx int) (string, bool) {
+	if !m.modeJump || m.paneInactive || dispIdx >= m.displayed {
+		return "", false
+	}
+	label, ok := jumpLabel(dispIdx, jumpLabelLen(m.displayed))
+	if !ok || !strings.HasPrefix(label, m.jumpInput) {
+		return "", false
+	}
+	return label, true
+}
+
+// jump moves the cursor to the entry with the typed label, selecting it when jumping selects.
+func (m *model) jump() tea.Cmd {
+	m.modeJump = false
+
+	dispIdx, ok := jumpIndex(m.jumpInput)
+	if !ok || dispIdx >= m.displayed {
+		return nil
+	}
+	m.setCursor(newPositionFromIndex(dispIdx, m.rows))
+	m.saveCursor()
+
+	if m.modeJumpSelect {
+		_, cmd := m.selectAction()
+		return cmd
+	}
+	return nil
+}
+
+func actionModeJump(m *model, msg tea.KeyMsg, esc bool) actionResult {
+	if esc || key.Matches(msg, keyEsc) {
+		m.modeJump = false
+		return newActionResult(nil)
+	}
+
+	if msg.Type != tea.KeyRunes || len(msg.Runes) != 1 || !strings.ContainsRune(jumpLabelChars, msg.Runes[0]) {
+		// Unconditional return to disable all other functionality.
+		return newActionResult(nil)
+	}
+
+	length := jumpLabelLen(m.displayed)
+	m.jumpInput += string(msg.Runes)
+	if len(m.jumpInput) < length {
+		// Cancel when no label starts with the typed characters.
+		first, _ := jumpIndex(m.jumpInput + strings.Repeat(jumpLabelChars[:1], length-len(m.jumpInput)))
+		if first >= m.displayed {
+			m.modeJump = false
+		}
+		return newActionResult(nil)
+	}
+	return newActionResult(m.jump())
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestJumpLabelLen(t *testing.T) {
+	tests := map[int]int{
+		1:      1,
+		26:     1,
+		27:     2,
+		676:    2,
+		677:    3,
+		100000: 3,
+	}
+	for n, expected := range tests {
+		if length := jumpLabelLen(n); length != expected {
+			t.Fatalf("expected length %d for %d entries, got %d", expected, n, length)
+		}
+	}
+}
+
+func TestJumpLabel(t *testing.T) {
+	for _, length := range []int{1, 2, 3} {
+		n := 1
+		for i := 0; i < length; i++ {
+			n *= len(jumpLabelChars)
+		}
+
+		seen := map[string]bool{}
+		for dispIdx := 0; dispIdx < n; dispIdx++ {
+			label, ok := jumpLabel(dispIdx, length)
+			if !ok {
+				t.Fatalf("expected label for index %d with length %d", dispIdx, length)
+			}
+			if len(label) != length {
+				t.Fatalf("expected label of length %d, got %q", length, label)
+			}
+			if seen[label] {
+				t.Fatalf("duplicate label %q", label)
+			}
+			seen[label] = true
+
+			idx, ok := jumpIndex(label)
+			if !ok || idx != dispIdx {
+				t.Fatalf("expected index %d for label %q, got %d", dispIdx, label, idx)
+			}
+		}
+
+		if _, ok := jumpLabel(n, length); ok {
+			t.Fatalf("expected no label for index %d with length %d", n, length)
+		}
+	}
+}
+
+func TestJumpLabelAtCursor(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"one", "two"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := newModel()
+	m.setPath(dir)
+	if err := m.list(); err != nil {
+		t.Fatal(err)
+	}
+	m.normalView()
+	m.modeJump = true
+
+	// The entry under the cursor is labeled like the other entries.
+	lines := strings.Split(m.normalView(), "\n")[1:]
+	for dispIdx, name := range []string{"one", "two"} {
+		label, _ := jumpLabel(dispIdx, 1)
+		line := lines[dispIdx]
+		if !strings.HasPrefix(line, label+" ") || !strings.Contains(line, name) {
+			t.Fatalf("expected %q labeled %q, got %q", name, label, line)
+		}
+	}
+}
@@ -5,12 +5,15 @@ import (
 	"unicode"
 
 	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 var (
 	keyQuit            = key.NewBinding(key.WithKeys("ctrl+c", "q"))
 	keyReturnDirectory = key.NewBinding(key.WithKeys("ctrl+d", "d"))
 	keyReturnSelected  = key.NewBinding(key.WithKeys("ctrl+x"))
+	keyYankSelected    = key.NewBinding(key.WithKeys("y"))
+	keyYankDirectory   = key.NewBinding(key.WithKeys("Y"))
 
 	keyEsc           = key.NewBinding(key.WithKeys("esc"))
 	keySelect        = key.NewBinding(key.WithKeys("enter"))
@@ -18,26 +21,126 @@ var (
 	keyTab           = key.NewBinding(key.WithKeys("tab"))
 	keyFileSeparator = key.NewBinding(key.WithKeys(fileSeparator))
 	keySpace         = key.NewBinding(key.WithKeys(" "))
+	keyHistoryPrev   = key.NewBinding(key.WithKeys("up"))
+	keyHistoryNext   = key.NewBinding(key.WithKeys("down"))
+
+	// Line editing keys for the search and prompt inputs.
+	keyLineWordLeft   = key.NewBinding(key.WithKeys("alt+b", "ctrl+left"))
+	keyLineWordRight  = key.NewBinding(key.WithKeys("alt+f", "ctrl+right"))
+	keyLineDelete     = key.NewBinding(key.WithKeys("delete"))
+	keyLineDeleteWord = key.NewBinding(key.WithKeys("ctrl+w"))
+	keyLineClear      = key.NewBinding(key.WithKeys("ctrl+u"))
 
 	keyMark    = key.NewBinding(key.WithKeys("ctrl+v"))
 	keyMarkAll = key.NewBinding(key.WithKeys("ctrl+a"))
+	keyBasket  = key.NewBinding(key.WithKeys("b"))
+	keyVisual  = key.NewBinding(key.WithKeys("v"))
+	keyJump    = key.NewBinding(key.WithKeys("s"))
+	keySaveSet = key.NewBinding(key.WithKeys("ctrl+s"))
+	keySets    = key.NewBinding(key.WithKeys("ctrl+o"))
+
+	// Keys in the sets view.
+	keySetDelete = key.NewBinding(key.WithKeys("x"))
+	keySetPrune  = key.NewBinding(key.WithKeys("p"))
+	keySetAdd    = key.NewBinding(key.WithKeys("a"))
+
+	keyMarkPattern   = key.NewBinding(key.WithKeys("+"))
+	keyUnmarkPattern = key.NewBinding(key.WithKeys("-"))
+	keyInvertMarks   = key.NewBinding(key.WithKeys("~"))
+	keyMarkType      = key.NewBinding(key.WithKeys("*"))
+
+	// Keys following keyMarkType, matching the trailing annotators of each type.
+	keyMarkTypeDir     = key.NewBinding(key.WithKeys("/"))
+	keyMarkTypeExec    = key.NewBinding(key.WithKeys("*"))
+	keyMarkTypeSymlink = key.NewBinding(key.WithKeys("@"))
 
 	keyUp    = key.NewBinding(key.WithKeys("up", "k"))
 	keyDown  = key.NewBinding(key.WithKeys("down", "j"))
 	keyLeft  = key.NewBinding(key.WithKeys("left", "h"))
 	keyRight = key.NewBinding(key.WithKeys("right", "l"))
 
+	keyFirst        = key.NewBinding(key.WithKeys("g")) // Pressed twice.
+	keyLast         = key.NewBinding(key.WithKeys("G"))
+	keyRowStart     = key.NewBinding(key.WithKeys("0"))
+	keyRowEnd       = key.NewBinding(key.WithKeys("$"))
+	keyScreenTop    = key.NewBinding(key.WithKeys("H"))
+	keyScreenMiddle = key.NewBinding(key.WithKeys("M"))
+	keyScreenBottom = key.NewBinding(key.WithKeys("L"))
+	keyColumnPrev   = key.NewBinding(key.WithKeys("{"))
+	keyColumnNext   = key.NewBinding(key.WithKeys("}"))
+	keyPageDown     = key.NewBinding(key.WithKeys("ctrl+f"))
+	keyPageUp       = key.NewBinding(key.WithKeys("ctrl+b"))
+
 	keyModeDebug  = key.NewBinding(key.WithKeys("d"))
-	keyModeHelp   = key.NewBinding(key.WithKeys("H"))
+	keyModeHelp   = key.NewBinding(key.WithKeys("?"))
 	keyModeSearch = key.NewBinding(key.WithKeys("i", "/"))
 
+	keyShellCommand = key.NewBinding(key.WithKeys("!"))
+	keyExCommand    = key.NewBinding(key.WithKeys(":"))
+	keyGoTo         = key.NewBinding(key.WithKeys("ctrl+g"))
+	keySubshell     = key.NewBinding(key.WithKeys("S"))
+
 	keyToggleFollowSymlink = key.NewBinding(key.WithKeys("f"))
 	keyToggleHidden        = key.NewBinding(key.WithKeys("a"))
-	keyToggleList          = key.NewBinding(key.WithKeys("L"))
+	keyToggleList          = key.NewBinding(key.WithKeys("w"))
+	keyToggleGitignore     = key.NewBinding(key.WithKeys("I"))
+	keyToggleDirSizes      = key.NewBinding(key.WithKeys("z"))
+
+	keySort = key.NewBinding(key.WithKeys("o"))
+
+	keyToggleDiskUsage = key.NewBinding(key.WithKeys("u"))
+	keyRescanDiskUsage = key.NewBinding(key.WithKeys("R"))
+
+	keyToggleTree    = key.NewBinding(key.WithKeys("T"))
+	keyToggleColumns = key.NewBinding(key.WithKeys("C"))
+	keyToggleDual    = key.NewBinding(key.WithKeys("D"))
+	keySwitchPane    = key.NewBinding(key.WithKeys("tab"))
+
+	keyNewTab   = key.NewBinding(key.WithKeys("ctrl+t"))
+	keyCloseTab = key.NewBinding(key.WithKeys("ctrl+w"))
+	keyNextTab  = key.NewBinding(key.WithKeys("]"))
+	keyPrevTab  = key.NewBinding(key.WithKeys("["))
+
+	keyCopy = key.NewBinding(key.WithKeys("c"))
+	keyMove = key.NewBinding(key.WithKeys("m"))
 
 	keyDismissError = key.NewBinding(key.WithKeys("e"))
 )
 
+// lineKeys are the cursor movement keys of a line input.
+type lineKeys struct {
+	left, right, start, end key.Binding
+}
+
+var (
+	promptLineKeys = lineKeys{
+		left:  key.NewBinding(key.WithKeys("left", "ctrl+b")),
+		right: key.NewBinding(key.WithKeys("right", "ctrl+f")),
+		start: key.NewBinding(key.WithKeys("home", "ctrl+a")),
+		end:   key.NewBinding(key.WithKeys("end", "ctrl+e")),
+	}
+
+	// The arrows and "ctrl+a" keep moving the cursor and marking entries while searching.
+	searchLineKeys = lineKeys{
+		left:  key.NewBinding(key.WithKeys("ctrl+b")),
+		right: key.NewBinding(key.WithKeys("ctrl+f")),
+		start: key.NewBinding(key.WithKeys("home")),
+		end:   key.NewBinding(key.WithKeys("end", "ctrl+e")),
+	}
+)
+
+// keyMsg returns the message sent when the first key of a binding is pressed.
+func keyMsg(binding key.Binding) tea.KeyMsg {
+	k := keyStringFirst(binding)
+	// Key types are negative for special keys and small positive values for control characters.
+	for t := tea.KeyType(-100); t < 128; t++ {
+		if t != tea.KeyRunes && t.String() == k {
+			return tea.KeyMsg{Type: t}
+		}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+}
+
 type remappedEscKey struct {
 	key     key.Binding
 	presses int
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// lineEditor is a single line input edited at a cursor. Edits operate on whole runes so that
+// multibyte characters are never split.
+type lineEditor struct {
+	value  string
+	cursor int // Byte offset of the cursor in the value.
+}
+
+// set replaces the value, moving the cursor to the end.
+func (e *lineEditor) set(value string) {
+	e.value = value
+	e.cursor = len(value)
+}
+
+func (e *lineEditor) insert(s string) {
+	e.value = e.value[:e.cursor] + s + e.value[e.cursor:]
+	e.cursor += len(s)
+}
+
+// backspace deletes the rune before the cursor.
+func (e *lineEditor) backspace() {
+	if e.cursor == 0 {
+		return
+	}
+	_, size := utf8.DecodeLastRuneInString(e.value[:e.cursor])
+	e.value = e.value[:e.cursor-size] + e.value[e.cursor:]
+	e.cursor -= size
+}
+
+// delete deletes the rune under the cursor.
+func (e *lineEditor) delete() {
+	if e.cursor == len(e.value) {
+		return
+	}
+	_, size := utf8.DecodeRuneInString(e.value[e.cursor:])
+	e.value = e.value[:e.cursor] + e.value[e.cursor+size:]
+}
+
+// deleteWord deletes the word before the cursor.
+func (e *lineEditor) deleteWord() {
+	start := e.wordStart()
+	e.value = e.value[:start] + e.value[e.cursor:]
+	e.cursor = start
+}
+
+func (e *lineEditor) left() {
+	_, size := utf8.DecodeLastRuneInString(e.value[:e.cursor])
+	e.cursor -= size
+}
+
+func (e *lineEditor) right() {
+	_, size := utf8.DecodeRuneInString(e.value[e.cursor:])
+	e.cursor += size
+}
+
+// wordStart returns the offset of the start of the word before the cursor, skipping any non-word
+// characters directly before the cursor.
+func (e *lineEditor) wordStart() int {
+	i := e.cursor
+	for inWord := false; i > 0; {
+		r, size := utf8.DecodeLastRuneInString(e.value[:i])
+		if !isWordRune(r) && inWord {
+			break
+		}
+		inWord = inWord || isWordRune(r)
+		i -= size
+	}
+	return i
+}
+
+// wordEnd returns the offset of the end of the word after the cursor, skipping any non-word
+// characters directly after the cursor.
+func (e *lineEditor) wordEnd() int {
+	i := e.cursor
+	for inWord := false; i < len(e.value); {
+		r, size := utf8.DecodeRuneInString(e.value[i:])
+		if !isWordRune(r) && inWord {
+			break
+		}
+		inWord = inWord || isWordRune(r)
+		i += size
+	}
+	return i
+}
+
+// isWordRune returns whether a rune is part of a word, so that words are delimited by separators
+// such as "/", ".", and "-" in addition to spaces.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// edit applies a key to the input with the given cursor movement keys, returning false if the key is
+// not an editing key.
+func (e *lineEditor) edit(msg tea.KeyMsg, keys lineKeys) bool {
+	switch {
+
+	case msg.Paste:
+		// Pasted line breaks cannot be part of a single line.
+		e.insert(strings.Map(func(r rune) rune {
+			if r == '\n' || r == '\r' {
+				return -1
+			}
+			return r
+		}, string(msg.Runes)))
+
+	case (msg.Type == tea.KeyRunes && !msg.Alt) || key.Matches(msg, keySpace):
+		e.insert(string(msg.Runes))
+
+	case key.Matches(msg, keyBack):
+		e.backspace()
+
+	case key.Matches(msg, keyLineDelete):
+		e.delete()
+
+	case key.Matches(msg, keyLineDeleteWord):
+		e.deleteWord()
+
+	case key.Matches(msg, keyLineClear):
+		e.set("")
+
+	case key.Matches(msg, keys.left):
+		e.left()
+
+	case key.Matches(msg, keys.right):
+		e.right()
+
+	case key.Matches(msg, keyLineWordLeft):
+		e.cursor = e.wordStart()
+
+	case key.Matches(msg, keyLineWordRight):
+		e.cursor = e.wordEnd()
+
+	case key.Matches(msg, keys.start):
+		e.cursor = 0
+
+	case key.Matches(msg, keys.end):
+		e.cursor = len(e.value)
+
+	default:
+		return false
+	}
+	return true
+}
+
+// render renders the input with a style following a label, displaying the cursor as the rune under
+// it in reverse video, or as a reversed space at the end of the input.
+func (e *lineEditor) render(style lipgloss.Style, label string) string {
+	under := " "
+	after := ""
+	if e.cursor < len(e.value) {
+		_, size := utf8.DecodeRuneInString(e.value[e.cursor:])
+		under = e.value[e.cursor : e.cursor+size]
+		after = e.value[e.cursor+size:]
+	}
+	output := style.Render(label+e.value[:e.cursor]) + style.Copy().Reverse(true).Render(under)
+	if after != "" {
+		output += style.Render(after)
+	}
+	return output
+}
@@ -0,0 +1,62 @@
+package main
+
+import "testing"
+
+func TestLineEditorRunes(t *testing.T) {
+	e := &lineEditor{}
+	e.insert("naïve")
+	e.left()
+	e.left()
+	e.left()
+	if e.cursor != 2 {
+		t.Fatalf("expected cursor at offset 2, got %d", e.cursor)
+	}
+	e.delete()
+	if e.value != "nave" {
+		t.Fatalf("expected multibyte rune to be deleted, got %q", e.value)
+	}
+	e.insert("ï")
+	e.backspace()
+	e.backspace()
+	if e.value != "nve" || e.cursor != 1 {
+		t.Fatalf("expected \"nve\" with cursor at offset 1, got %q with cursor at %d", e.value, e.cursor)
+	}
+
+	e.set("café")
+	e.backspace()
+	if e.value != "caf" {
+		t.Fatalf("expected multibyte rune to be deleted, got %q", e.value)
+	}
+}
+
+func TestLineEditorWords(t *testing.T) {
+	e := &lineEditor{}
+	e.set("src/nav/main.go")
+
+	tests := []struct {
+		move     func()
+		expected int
+	}{
+		{move: func() { e.cursor = e.wordStart() }, expected: 13},
+		{move: func() { e.cursor = e.wordStart() }, expected: 8},
+		{move: func() { e.cursor = e.wordStart() }, expected: 4},
+		{move: func() { e.cursor = e.wordEnd() }, expected: 7},
+		{move: func() { e.cursor = e.wordEnd() }, expected: 12},
+	}
+	for i, test := range tests {
+		test.move()
+		if e.cursor != test.expected {
+			t.Fatalf("expected cursor at offset %d after move %d, got %d", test.expected, i, e.cursor)
+		}
+	}
+
+	e.cursor = len(e.value)
+	e.deleteWord()
+	if e.value != "src/nav/main." {
+		t.Fatalf("expected last word to be deleted, got %q", e.value)
+	}
+	e.deleteWord()
+	if e.value != "src/nav/" {
+		t.Fatalf("expected separator and word to be deleted, got %q", e.value)
+	}
+}
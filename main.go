@@ -25,17 +25,35 @@ const (
 	flagVersionShort        = "-v"
 	flagSearch              = "--search"
 	flagSearchShort         = "-s"
+	flagSet                 = "--set"
 	flagPipe                = "--pipe"
+	flagChooseDir           = "--choosedir"
+	flagChooseFiles         = "--choosefiles"
+	flagColumns             = "--columns"
+	flagColumnsShort        = "-c"
 	flagFollowSymlinks      = "--follow"
 	flagFollowSymlinksShort = "-f"
 	flagHidden              = "--hidden"
 	flagHiddenShort         = "-a"
+	flagHideGitignore       = "--hide-gitignored"
+	flagHideGitignoreShort  = "-g"
+	flagDirSizes            = "--dir-sizes"
+	flagDiskUsage           = "--disk-usage"
+	flagDiskUsageShort      = "-u"
+	flagDual                = "--dual"
+	flagMarkOrder           = "--mark-order"
+	flagJumpSelect          = "--jump-select"
 	flagList                = "--list"
 	flagListShort           = "-l"
 	flagNoColor             = "--no-color"
+	flagNoGit               = "--no-git"
+	flagNoMouse             = "--no-mouse"
 	flagNoStatusBar         = "--no-status-bar"
 	flagNoTrailing          = "--no-trailing"
 	flagRemapEsc            = "--remap-esc"
+	flagSort                = "--sort"
+	flagTree                = "--tree"
+	flagTreeShort           = "-t"
 )
 
 func main() {
@@ -44,14 +62,26 @@ func main() {
 	// Initialize model with defaults.
 	m := newModel()
 
+	// Set model options from the configuration file.
+	err = m.loadConfig()
+	if err != nil {
+		exit(err, m.exitCode)
+	}
+
 	// Set model options from args.
 	err = parseArgs(os.Args[1:], m)
 	if err != nil {
 		exit(err, m.exitCode)
 	}
 
+	// Return a saved selection set without starting the program.
+	if m.returnSetName != "" {
+		err = m.returnSet(m.returnSetName)
+		exit(err, m.exitCode)
+	}
+
 	// Populate the model.
-	err = m.list()
+	err = m.listTabs()
 	if err != nil {
 		exit(err, m.exitCode)
 	}
@@ -61,7 +91,17 @@ func main() {
 	lipgloss.SetColorProfile(output.ColorProfile())
 
 	// Run the app.
-	_, err = tea.NewProgram(m, tea.WithOutput(os.Stderr)).Run()
+	opts := []tea.ProgramOption{tea.WithOutput(os.Stderr)}
+	if !m.noMouse {
+		opts = append(opts, tea.WithMouseCellMotion())
+	}
+	_, err = tea.NewProgram(m, opts...).Run()
+	if err != nil {
+		exit(err, m.exitCode)
+	}
+
+	// Write the final directory and returned paths to files if requested.
+	err = m.writeChoiceFiles()
 	if err != nil {
 		exit(err, m.exitCode)
 	}
@@ -72,6 +112,8 @@ func main() {
 func parseArgs(args []string, m *model) error {
 	var err error
 
+	paths := []string{}
+
 	i := 0
 	for i < len(args) {
 		arg := args[i]
@@ -83,20 +125,55 @@ func parseArgs(args []string, m *model) error {
 			versionAndExit()
 		case flagHidden, flagHiddenShort:
 			m.modeHidden = true
+		case flagHideGitignore, flagHideGitignoreShort:
+			m.modeHideGitignore = true
+		case flagDirSizes:
+			m.modeDirSizes = true
+		case flagDiskUsage, flagDiskUsageShort:
+			m.modeDiskUsage = true
 		case flagList, flagListShort:
 			m.modeList = true
+		case flagTree, flagTreeShort:
+			m.modeTree = true
+		case flagColumns, flagColumnsShort:
+			m.modeColumns = true
+		case flagDual:
+			m.modeDual = true
 		case flagSearch, flagSearchShort:
 			m.modeSearch = true
 		case flagPipe:
 			m.modeSubshell = true
+		case flagMarkOrder:
+			m.modeMarkOrder = true
+		case flagJumpSelect:
+			m.modeJumpSelect = true
+		case flagChooseDir, flagChooseFiles:
+			if i > len(args)-2 {
+				return fmt.Errorf("%s must be followed by a file path", arg)
+			}
+			path, err := filepath.Abs(args[i+1])
+			if err != nil {
+				return err
+			}
+			if arg == flagChooseDir {
+				m.chooseDirFile = path
+			} else {
+				m.chooseFilesFile = path
+			}
+			i += 2
+			continue
 		case flagFollowSymlinks, flagFollowSymlinksShort:
 			m.modeFollowSymlink = true
 		case flagNoColor:
 			m.modeColor = false
+		case flagNoGit:
+			m.modeGit = false
 		case flagNoTrailing:
 			m.modeTrailing = false
 		case flagNoStatusBar:
 			m.hideStatusBar = true
+		case flagNoMouse:
+			m.noMouse = true
 		case flagRemapEsc:
 			if i > len(args)-2 {
 				return fmt.Errorf("%s must be followed by a string value", flagRemapEsc)
@@ -107,24 +184,51 @@ func parseArgs(args []string, m *model) error {
 			}
 			i += 2
 			continue
+		case flagSet:
+			if i > len(args)-2 {
+				return fmt.Errorf("%s must be followed by a set name", flagSet)
+			}
+			m.returnSetName = args[i+1]
+			i += 2
+			continue
+		case flagSort:
+			if i > len(args)-2 {
+				return fmt.Errorf("%s must be followed by a sort mode", flagSort)
+			}
+			m.sortMode, err = parseSortMode(args[i+1])
+			if err != nil {
+				return err
+			}
+			i += 2
+			continue
 		default:
 			if strings.HasPrefix(arg, "-") {
 				return fmt.Errorf("unknown flag: %s", arg)
 			}
-			m.path, err = filepath.Abs(arg)
+			path, err := filepath.Abs(arg)
 			if err != nil {
 				return err
 			}
+			paths = append(paths, path)
 		}
 
 		i++
 	}
 
-	if m.path == "" {
-		m.path, err = os.Getwd()
+	if len(paths) == 0 {
+		path, err := os.Getwd()
 		if err != nil {
 			return err
 		}
+		paths = append(paths, path)
+	}
+
+	// Open a tab for each path.
+	m.path = paths[0]
+	for _, path := range paths[1:] {
+		t := newTab(path)
+		t.modeDual = m.modeDual
+		m.tabs = append(m.tabs, t)
 	}
 
 	return nil
@@ -141,7 +245,7 @@ func exit(err error, code int) {
 }
 
 func usageAndExit() {
-	fmt.Printf("%s\n%s\n%s\n", usage(), commands(), flags())
+	fmt.Printf("%s\n%s\n%s\n%s\n", usage(), commands(), exCommandsUsage(), flags())
 	os.Exit(0)
 }
 
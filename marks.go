@@ -1,52 +1,54 @@
 package main
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
 
 func (m *model) marked() bool {
 	return m.markedIndex(m.displayIndex())
 }
 
 func (m *model) markedIndex(dispIdx int) bool {
-	_, marked := m.marks[dispIdx]
+	ent, ok := m.displayedEntry(dispIdx)
+	if !ok {
+		return false
+	}
+	_, marked := m.marks[ent.path()]
 	return marked
 }
 
-func (m *model) reloadMarks() error {
-	if len(m.marks) == 0 {
-		return nil
-	}
-	newMarks := make(map[int]int)
+// displayedEntry returns the entry at a display index.
+func (m *model) displayedEntry(dispIdx int) (*entry, bool) {
 	cache, ok := m.pathCache[m.path]
-	if !ok || !cache.hasIndexes() {
-		return errors.New("failed to load page cache indexes")
+	if !ok {
+		return nil, false
 	}
-	for _, entryIdx := range m.marks {
-		if newDisplayIdx, ok := cache.lookupDisplayIndex(entryIdx); ok {
-			newMarks[newDisplayIdx] = entryIdx
-		}
+	entryIdx, ok := cache.lookupEntryIndex(dispIdx)
+	if !ok || entryIdx >= len(m.entries) {
+		return nil, false
 	}
-	m.marks = newMarks
-	return nil
+	return m.entries[entryIdx], true
 }
 
 func (m *model) toggleMark() error {
-	idx := m.displayIndex()
-	if m.markedIndex(idx) {
-		delete(m.marks, idx)
-		m.modeMarks = len(m.marks) != 0
-		return nil
-	}
-
-	cache, ok := m.pathCache[m.path]
-	if !ok || !cache.hasIndexes() {
-		return errors.New("failed to load page cache indexes")
-	}
-	entryIdx, ok := cache.lookupEntryIndex(idx)
+	ent, ok := m.displayedEntry(m.displayIndex())
 	if !ok {
 		return errors.New("failed to find entry index")
 	}
-	m.marks[idx] = entryIdx
-	m.modeMarks = true
+	if _, marked := m.marks[ent.path()]; marked {
+		m.unmark(ent.path())
+		return nil
+	}
+	m.mark(ent.path())
 	return nil
 }
 
@@ -54,35 +56,214 @@ func (m *model) toggleMarkAll() error {
 	// Check if all displayed entries are marked to determine toggle behavior.
 	allMarked := true
 	for i := 0; i < m.displayed; i++ {
-		if _, marked := m.marks[i]; !marked {
+		if !m.markedIndex(i) {
 			allMarked = false
 			break
 		}
 	}
 
 	if allMarked {
-		m.clearMarks()
+		for i := 0; i < m.displayed; i++ {
+			if ent, ok := m.displayedEntry(i); ok {
+				m.unmark(ent.path())
+			}
+		}
 		return nil
 	}
 	return m.markAll()
 }
 
 func (m *model) markAll() error {
-	m.marks = make(map[int]int)
-	cache, ok := m.pathCache[m.path]
-	if !ok || !cache.hasIndexes() {
+	if cache, ok := m.pathCache[m.path]; !ok || !cache.hasIndexes() {
 		return errors.New("failed to load page cache indexes")
 	}
 	for i := 0; i < m.displayed; i++ {
-		if entryIdx, ok := cache.lookupEntryIndex(i); ok {
-			m.marks[i] = entryIdx
+		if ent, ok := m.displayedEntry(i); ok && !m.markedIndex(i) {
+			m.mark(ent.path())
 		}
 	}
-	m.modeMarks = len(m.marks) != 0
 	return nil
 }
 
+// mark marks a path, recording the order in which it was marked.
+func (m *model) mark(path string) {
+	m.markCount++
+	m.marks[path] = m.markCount
+	m.markSort = nil
+	m.modeMarks = true
+}
+
+func (m *model) unmark(path string) {
+	delete(m.marks, path)
+	m.markSort = nil
+	m.modeMarks = len(m.marks) != 0
+}
+
 func (m *model) clearMarks() {
-	m.marks = make(map[int]int)
+	m.marks = make(map[string]int)
+	m.markSort = nil
 	m.modeMarks = false
 }
+
+// markedPaths returns the marked paths in the order they were marked when returning in marked
+// order and sorted otherwise. The sorted paths are kept until the marks change to avoid reading
+// each marked path whenever the marks are displayed.
+func (m *model) markedPaths() []string {
+	if !m.modeMarkOrder && m.markSort != nil {
+		return append([]string{}, m.markSort...)
+	}
+
+	paths := make([]string, 0, len(m.marks))
+	for path := range m.marks {
+		paths = append(paths, path)
+	}
+	if m.modeMarkOrder {
+		sort.Slice(paths, func(i, j int) bool {
+			return m.marks[paths[i]] < m.marks[paths[j]]
+		})
+		return paths
+	}
+	sortPaths(paths)
+	m.markSort = append([]string{}, paths...)
+	return paths
+}
+
+// sortPaths performs an in-place sort of paths by type (mode) as entries are sorted, with
+// directories first and hidden entries last, and by path within each type (mode). Paths that can
+// no longer be read are sorted last.
+func sortPaths(paths []string) {
+	entries := []*entry{}
+	missing := []string{}
+	for _, path := range paths {
+		info, err := os.Lstat(path)
+		if err != nil {
+			missing = append(missing, path)
+			continue
+		}
+		ent, err := newEntry(fs.FileInfoToDirEntry(info))
+		if err != nil {
+			missing = append(missing, path)
+			continue
+		}
+		ent.dir = filepath.Dir(path)
+		entries = append(entries, ent)
+	}
+
+	sortEntriesWith(entries, func(iEntry, jEntry *entry) bool {
+		return iEntry.path() < jEntry.path()
+	})
+	sort.Strings(missing)
+
+	for i, ent := range entries {
+		paths[i] = ent.path()
+	}
+	copy(paths[len(entries):], missing)
+}
+
+// returnedPaths returns the paths returned for the marked entries, or the entry under the cursor
+// if none are marked, following symlinks.
+func (m *model) returnedPaths() ([]string, error) {
+	paths := m.markedPaths()
+	if !m.modeMarks {
+		selected, err := m.selected()
+		if err != nil {
+			return nil, err
+		}
+		paths = []string{selected.path()}
+	}
+
+	for i, path := range paths {
+		returned, err := returnedPath(path)
+		if err != nil {
+			return nil, err
+		}
+		paths[i] = returned
+	}
+	return paths, nil
+}
+
+// returnedPath returns the path returned for an entry path, following symlinks.
+func returnedPath(path string) (string, error) {
+	info, err := os.Lstat(path)
+	if err != nil || info.Mode()&os.ModeSymlink == 0 {
+		// Paths that no longer exist are returned unchanged.
+		return path, nil
+	}
+	return filepath.EvalSymlinks(path)
+}
+
+// invertMarks toggles the marks of all displayed entries.
+func (m *model) invertMarks() {
+	for i := 0; i < m.displayed; i++ {
+		if ent, ok := m.displayedEntry(i); ok {
+			if m.markedIndex(i) {
+				m.unmark(ent.path())
+			} else {
+				m.mark(ent.path())
+			}
+		}
+	}
+}
+
+// markType marks all displayed entries with the provided mode.
+func (m *model) markType(mode entryMode) {
+	for i := 0; i < m.displayed; i++ {
+		if ent, ok := m.displayedEntry(i); ok && ent.hasMode(mode) && !m.markedIndex(i) {
+			m.mark(ent.path())
+		}
+	}
+}
+
+// markPattern marks or unmarks all displayed entries with names matching a glob pattern, or a
+// regular expression when the pattern is enclosed in slashes.
+func (m *model) markPattern(pattern string, mark bool) error {
+	matches, err := namePattern(pattern)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < m.displayed; i++ {
+		ent, ok := m.displayedEntry(i)
+		if !ok || !matches(ent.Name()) {
+			continue
+		}
+		if mark && !m.markedIndex(i) {
+			m.mark(ent.path())
+		} else if !mark {
+			m.unmark(ent.path())
+		}
+	}
+	return nil
+}
+
+func (m *model) openMarkPatternPrompt(mark bool) {
+	mode, label, status := "MARK", "mark: ", "failed to mark entries"
+	if !mark {
+		mode, label, status = "UNMARK", "unmark: ", "failed to unmark entries"
+	}
+	m.openPrompt(newPrompt(mode, label, func(m *model, value string) tea.Cmd {
+		if err := m.markPattern(value, mark); err != nil {
+			m.setError(err, status)
+		}
+		return nil
+	}))
+}
+
+// namePattern returns a function reporting whether a name matches a glob pattern, or a regular
+// expression when the pattern is enclosed in slashes.
+func namePattern(pattern string) (func(name string) bool, error) {
+	if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		re, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			return nil, err
+		}
+		return re.MatchString, nil
+	}
+
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	return func(name string) bool {
+		matched, _ := filepath.Match(pattern, name)
+		return matched
+	}, nil
+}
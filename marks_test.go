@@ -0,0 +1,128 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNamePattern(t *testing.T) {
+	tests := map[string]struct {
+		pattern string
+		matches []string
+		misses  []string
+		err     bool
+	}{
+		"glob": {
+			pattern: "*.go",
+			matches: []string{"main.go", ".go"},
+			misses:  []string{"main.go.orig", "go"},
+		},
+		"regex": {
+			pattern: "/^test_.*\\.py$/",
+			matches: []string{"test_a.py"},
+			misses:  []string{"a_test.py", "test_a.pyc"},
+		},
+		"single_slash_is_glob": {
+			pattern: "/",
+			misses:  []string{"a"},
+		},
+		"invalid_glob": {
+			pattern: "[",
+			err:     true,
+		},
+		"invalid_regex": {
+			pattern: "/(/",
+			err:     true,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(tt *testing.T) {
+			matches, err := namePattern(test.pattern)
+			if test.err {
+				if err == nil {
+					tt.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				tt.Fatalf("unexpected error: %v", err)
+			}
+			for _, name := range test.matches {
+				if !matches(name) {
+					tt.Errorf("expected %q to match %q", name, test.pattern)
+				}
+			}
+			for _, name := range test.misses {
+				if matches(name) {
+					tt.Errorf("expected %q not to match %q", name, test.pattern)
+				}
+			}
+		})
+	}
+}
+
+func TestSortPaths(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"b", ".d"} {
+		if err := os.Mkdir(filepath.Join(dir, name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"a", ".c"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	paths := []string{}
+	for _, name := range []string{"missing", ".c", "a", ".d", "b"} {
+		paths = append(paths, filepath.Join(dir, name))
+	}
+	sortPaths(paths)
+
+	expected := []string{"b", "a", ".d", ".c", "missing"}
+	for i, name := range expected {
+		if paths[i] != filepath.Join(dir, name) {
+			t.Fatalf("expected %s at index %d, got %s", name, i, paths[i])
+		}
+	}
+}
+
+func TestMarkedPathsSortedOnChange(t *testing.T) {
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a"), filepath.Join(dir, "b")
+	if err := os.WriteFile(a, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(b, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	m := newModel()
+	m.mark(a)
+	m.mark(b)
+	expectPaths := func(expected ...string) {
+		paths := m.markedPaths()
+		for i, path := range expected {
+			if paths[i] != path {
+				t.Fatalf("expected %s at index %d, got %s", path, i, paths[i])
+			}
+		}
+	}
+	expectPaths(b, a)
+
+	// Replacing the directory with a file does not change the order until the marks change.
+	if err := os.Remove(b); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(b, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	expectPaths(b, a)
+	m.unmark(a)
+	m.mark(a)
+	expectPaths(a, b)
+}
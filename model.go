@@ -7,95 +7,226 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+
+	"github.com/dkaslovsky/nav/internal/gitignore"
 )
 
 var fileSeparator = string(filepath.Separator)
 
 type model struct {
-	path      string
-	prevPath  string
-	entries   []*entry
-	displayed int
+	*tab        // Active tab.
+	tabs []*tab // Tabs in display order.
+
 	exitCode  int
 	exitStr   string
+	exitPaths []string
 	error     error
 	errorStr  string
+	info      string // Confirmation of a completed action, cleared by the next key press.
 	esc       *remappedEscKey
-	search    string
-	pathCache map[string]*cacheItem // Map path to cached state.
-	marks     map[int]int           // Map display index to entry index for marked entries.
+	prompt    *prompt
+	gitignore *gitignore.Cache // Loaded when first needed, nil until then.
+	sortMode  sortMode
+
+	paneInactive bool   // Whether the pane being displayed does not have focus.
+	basketCursor int    // Index of the cursor in the selection basket.
+	visualAnchor int    // Display index at which visual mode was entered.
+	jumpInput    string // Characters of a jump label typed in jump mode.
+	keyPrefix    string // Pending first key of a multiple key command.
+	countPrefix  int    // Pending count typed before a command.
+	count        int    // Count typed before the key being handled, or zero if none was typed.
+	lastClick    click  // Most recent mouse click, used to detect double clicks.
+	clipboardSeq string // Clipboard escape sequence written with the view until the next key press.
+
+	sets       []string   // Names of saved selection sets displayed in the sets view.
+	setsInfo   []*setInfo // Path counts of the saved selection sets, nil for unreadable sets.
+	setsCursor int        // Index of the cursor in the sets view.
+	setName    string     // Name of the set opened for editing in the sets view.
+	setPaths   []string   // Paths of the set opened for editing.
+	setStale   []bool     // Whether each path of the opened set no longer exists.
 
-	c       int // Cursor column position.
-	r       int // Cursor row position.
-	columns int // Displayed columns.
-	rows    int // Displayed columns.
-	width   int // Terminal width.
-	height  int // Terminal height.
+	width  int // Terminal width.
+	height int // Terminal height.
 
+	modeBasket        bool
 	modeColor         bool
+	modeColumns       bool
 	modeDebug         bool
+	modeDirSizes      bool
+	modeDiskUsage     bool
 	modeError         bool
 	modeExit          bool
 	modeFollowSymlink bool
+	modeGit           bool
 	modeHelp          bool
 	modeHidden        bool
+	modeHideGitignore bool
+	modeJump          bool
+	modeJumpSelect    bool
 	modeList          bool
-	modeMarks         bool
+	modeMarkOrder     bool
+	modePrompt        bool
 	modeSearch        bool
+	modeSets          bool
 	modeSubshell      bool
 	modeTrailing      bool
+	modeTree          bool
+	modeVisual        bool
 
 	hideStatusBar bool
+	noMouse       bool
+
+	chooseDirFile   string // File to write the final directory on exit.
+	chooseFilesFile string // File to write the returned paths on exit.
+	returnSetName   string // Selection set to return without starting the program.
+
+	openers      []*opener // Configured commands for opening files.
+	hidePatterns []string  // Configured patterns for names of entries to hide.
+
+	dirSizes        map[string]*dirSizeResult // Map directory path to its computed total size.
+	dirSizesPending map[string]bool           // Set of directory paths with sizes being computed.
+	spinner         spinner.Model             // Displayed for pending directory sizes.
+	spinning        bool                      // Whether the spinner is ticking.
+
+	diskUsageRoot     *diskUsageNode            // Result of the most recent disk usage scan.
+	diskUsagePath     string                    // Path of the most recent disk usage scan.
+	diskUsageScanning string                    // Path of the disk usage scan in progress.
+	promptHistories   map[string]*promptHistory // Map prompt mode to its history.
+
+	previews        map[string]*preview // Map directory path to its entries displayed in a side pane.
+	previewsPending map[string]bool     // Set of directory paths with side pane entries being loaded.
 }
 
 func newModel() *model {
+	t := newTab("")
 	return &model{
-		width:     80,
-		height:    60,
-		esc:       defaultEscRemapKey(),
-		pathCache: make(map[string]*cacheItem),
-		marks:     make(map[int]int),
+		tab:    t,
+		tabs:   []*tab{t},
+		width:  80,
+		height: 60,
+		esc:    defaultEscRemapKey(),
 
+		promptHistories: make(map[string]*promptHistory),
+		dirSizes:        make(map[string]*dirSizeResult),
+		dirSizesPending: make(map[string]bool),
+		spinner:         spinner.New(spinner.WithSpinner(spinner.MiniDot)),
+		previews:        make(map[string]*preview),
+		previewsPending: make(map[string]bool),
+
+		modeBasket:        false,
 		modeColor:         true,
+		modeColumns:       false,
 		modeDebug:         false,
+		modeDirSizes:      false,
+		modeDiskUsage:     false,
 		modeError:         false,
 		modeExit:          false,
 		modeFollowSymlink: false,
+		modeGit:           true,
 		modeHelp:          false,
 		modeHidden:        false,
+		modeHideGitignore: false,
+		modeJump:          false,
+		modeJumpSelect:    false,
 		modeList:          false,
-		modeMarks:         false,
+		modeMarkOrder:     false,
+		modePrompt:        false,
 		modeSearch:        false,
+		modeSets:          false,
 		modeSubshell:      false,
 		modeTrailing:      true,
+		modeTree:          false,
+		modeVisual:        false,
 
 		hideStatusBar: false,
+		noMouse:       false,
 	}
 }
 
 func (m *model) normalMode() bool {
-	return !(m.modeSearch || m.modeDebug || m.modeHelp)
+	return !(m.modeSearch || m.modeDebug || m.modeHelp || m.modePrompt || m.modeBasket || m.modeSets || m.modeVisual || m.modeJump)
 }
 
 func (m *model) list() error {
-	files, err := os.ReadDir(m.path)
+	entries, err := m.readDir(m.path, 0)
 	if err != nil {
 		return err
 	}
+	m.entries = m.sortTree(entries)
+	m.listed++
+	// Reload the directory when it is next displayed in a side pane to show any changes since its
+	// preview was loaded.
+	delete(m.previews, m.path)
+
+	return nil
+}
+
+// readDir reads the entries of a directory at the provided tree depth followed by the entries of
+// each expanded subdirectory in tree mode.
+func (m *model) readDir(dir string, depth int) ([]*entry, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	hiddenNames := readHiddenFile(dir)
 
-	m.entries = []*entry{}
+	entries := []*entry{}
 	for _, file := range files {
 		ent, err := newEntry(file)
 		if err != nil {
-			return err
+			return nil, err
+		}
+		ent.dir = dir
+		ent.depth = depth
+		if m.hideEntry(ent, hiddenNames) {
+			ent.mode = ent.mode | entryModeHidden
+		}
+		entries = append(entries, ent)
+
+		if m.modeTree && ent.hasMode(entryModeDir) && m.expanded(ent.path()) {
+			// Unreadable subdirectories are displayed without entries.
+			if children, err := m.readDir(ent.path(), depth+1); err == nil {
+				entries = append(entries, children...)
+			}
 		}
-		m.entries = append(m.entries, ent)
 	}
-	sortEntries(m.entries)
+	return entries, nil
+}
 
+// relist lists the current directory again, keeping the cursor on the same entry.
+func (m *model) relist() error {
+	prev := m.entries
+	if err := m.list(); err != nil {
+		return err
+	}
+	m.remapEntries(prev)
 	return nil
 }
 
+// remapEntries updates the cached index mappings after the entries of the current directory have
+// been replaced or reordered, matching the previous entries by path.
+func (m *model) remapEntries(prev []*entry) {
+	entryIdx := make(map[string]int, len(m.entries))
+	for i, ent := range m.entries {
+		entryIdx[ent.path()] = i
+	}
+
+	// Map previous entry index to the new entry index.
+	remap := make(map[int]int, len(prev))
+	for i, ent := range prev {
+		if j, ok := entryIdx[ent.path()]; ok {
+			remap[i] = j
+		}
+	}
+
+	if cache, ok := m.pathCache[m.path]; ok {
+		cache.remapEntryIndexes(remap)
+	}
+}
+
 func (m *model) selected() (*entry, error) {
 	cache, ok := m.pathCache[m.path]
 	if !ok {
@@ -112,9 +243,14 @@ func (m *model) selected() (*entry, error) {
 }
 
 func (m *model) location() string {
-	location := m.path
+	return displayPath(m.path)
+}
+
+// displayPath formats a path for display, abbreviating the home directory.
+func displayPath(path string) string {
+	location := path
 	if userHomeDir, err := os.UserHomeDir(); err == nil {
-		location = strings.Replace(m.path, userHomeDir, "~", 1)
+		location = strings.Replace(path, userHomeDir, "~", 1)
 	}
 	if runtime.GOOS == "windows" {
 		location = strings.ReplaceAll(strings.Replace(location, "\\/", fileSeparator, 1), "/", fileSeparator)
@@ -123,15 +259,27 @@ func (m *model) location() string {
 }
 
 func (m *model) displayNameOpts() []displayNameOption {
+	opts := []displayNameOption{}
+	if m.modeDiskUsage {
+		opts = append(opts, displayNameWithDiskUsage(m.diskUsageInfo))
+	}
+	return append(opts, m.entryDisplayNameOpts()...)
+}
+
+// entryDisplayNameOpts returns the display name options that apply to entries of any directory.
+func (m *model) entryDisplayNameOpts() []displayNameOption {
 	opts := []displayNameOption{}
 	if m.modeColor {
 		opts = append(opts, displayNameWithColor())
 	}
 	if m.modeFollowSymlink {
-		opts = append(opts, displayNameWithFollowSymlink(m.path))
+		opts = append(opts, displayNameWithFollowSymlink())
 	}
 	if m.modeList {
-		opts = append(opts, displayNameWithList())
+		opts = append(opts, displayNameWithList(m.listSize))
+	}
+	if status := m.gitStatus(); status != nil {
+		opts = append(opts, displayNameWithGitStatus(status))
 	}
 	if m.modeTrailing {
 		opts = append(opts, displayNameWithTrailing())
@@ -179,7 +327,7 @@ func (m *model) setExitWithCode(exitStr string, exitCode int) {
 
 func (m *model) clearSearch() {
 	m.modeSearch = false
-	m.search = ""
+	m.search.set("")
 }
 
 func index(c int, r int, rows int) int {
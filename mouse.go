@@ -0,0 +1,178 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// doubleClickInterval is the maximum time between two clicks at the same position for them to be
+// treated as a double click.
+const doubleClickInterval = 500 * time.Millisecond
+
+type click struct {
+	x    int
+	y    int
+	time time.Time
+}
+
+func actionMouse(m *model, msg tea.MouseMsg, esc bool) actionResult {
+	if !m.normalMode() && !m.modeVisual {
+		return newActionResult(nil)
+	}
+
+	switch {
+
+	case msg.Button == tea.MouseButtonWheelUp:
+		m.moveUp()
+
+	case msg.Button == tea.MouseButtonWheelDown:
+		m.moveDown()
+
+	case msg.Button == tea.MouseButtonLeft && msg.Action == tea.MouseActionPress:
+		return newActionResult(m.click(msg.X, msg.Y))
+
+	default:
+		return newActionResult(nil)
+	}
+
+	return newActionResultNoop()
+}
+
+// click handles a left click at a terminal position. Clicking an entry moves the cursor to it and
+// double clicking selects it. Clicking a path segment of the location bar navigates to that
+// directory. In dual pane mode, the clicked pane is focused.
+func (m *model) click(x int, y int) tea.Cmd {
+	double := m.lastClick.x == x && m.lastClick.y == y && time.Since(m.lastClick.time) < doubleClickInterval
+	m.lastClick = click{x: x, y: y, time: time.Now()}
+	if double {
+		// Reset to avoid treating a third click as another double click.
+		m.lastClick = click{}
+	}
+
+	if m.showTabBar() {
+		y--
+	}
+
+	if m.modeDual {
+		// Account for the space separating the panes.
+		width := m.paneWidth() + 1
+		p := x / width
+		if p >= len(m.panes) || x%width == width-1 {
+			return nil
+		}
+		if m.panes[p] != m.pane {
+			if !m.normalMode() {
+				return nil
+			}
+			m.switchPane()
+		}
+		x -= p * width
+	}
+
+	if y == 0 {
+		if !m.modeError && m.normalMode() {
+			m.clickLocation(x)
+		}
+		return nil
+	}
+
+	if m.modeColumns {
+		parentWidth, currentWidth, _ := m.columnsWidths()
+		x -= parentWidth
+		if x >= currentWidth-1 {
+			return nil
+		}
+	}
+
+	dispIdx, ok := m.gridIndexAt(x, y-1)
+	if !ok {
+		return nil
+	}
+	m.setCursor(newPositionFromIndex(dispIdx, m.rows))
+	m.saveCursor()
+
+	if double && m.normalMode() {
+		_, cmd := m.selectAction()
+		return cmd
+	}
+	return nil
+}
+
+// gridIndexAt returns the display index of the entry rendered at a position relative to the first
+// row of the grid, inverting the column-major layout.
+func (m *model) gridIndexAt(x int, y int) (int, bool) {
+	if x < 0 || y < 0 || y >= m.rows {
+		return 0, false
+	}
+
+	start := 0
+	for c, l := range m.columnLens {
+		// Each column is rendered with a cursor prefix and padding totaling the separator length.
+		end := start + l + columnSeparatorLen
+		if x < end {
+			idx := index(c, y, m.rows)
+			return idx, idx < m.displayed
+		}
+		start = end
+	}
+	return 0, false
+}
+
+// clickLocation navigates to the directory of the location bar path segment at position x, with
+// the cursor on the entry leading back to the current directory.
+func (m *model) clickLocation(x int) {
+	ancestor, ok := locationAncestor(m.path, m.location(), x)
+	if !ok || ancestor == m.path {
+		return
+	}
+
+	rel, err := filepath.Rel(ancestor, m.path)
+	if err != nil {
+		m.setError(err, "failed to evaluate path")
+		return
+	}
+	child := filepath.Join(ancestor, strings.Split(rel, fileSeparator)[0])
+	if err := m.goToPath(child); err != nil {
+		m.setError(err, err.Error())
+	}
+}
+
+// locationAncestor returns the ancestor of path whose name is displayed at position x of its
+// displayed location. The displayed location must end with the same characters as path, differing
+// only in an abbreviated prefix.
+func locationAncestor(path string, location string, x int) (string, bool) {
+	// Find the byte offset of the rune displayed at x.
+	offset := -1
+	width := 0
+	for i, r := range location {
+		width += lipgloss.Width(string(r))
+		if width > x {
+			offset = i
+			break
+		}
+	}
+	if offset < 0 {
+		return "", false
+	}
+
+	// Extend the location to the end of the clicked path segment.
+	end := len(location)
+	if i := strings.Index(location[offset:], fileSeparator); i >= 0 {
+		end = offset + i
+	}
+	remaining := len(location) - end
+	if remaining > len(path) {
+		return "", false
+	}
+
+	ancestor := path[:len(path)-remaining]
+	if ancestor == "" {
+		// The leading separator of the root directory was clicked.
+		return fileSeparator, true
+	}
+	return ancestor, true
+}
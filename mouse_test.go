@@ -0,0 +1,82 @@
+package main
+
+import "testing"
+
+func TestLocationAncestor(t *testing.T) {
+	tests := map[string]struct {
+		path     string
+		location string
+		x        int
+		expected string
+		ok       bool
+	}{
+		"first_segment": {
+			path:     "/usr/local/bin",
+			location: "/usr/local/bin",
+			x:        2,
+			expected: "/usr",
+			ok:       true,
+		},
+		"middle_segment": {
+			path:     "/usr/local/bin",
+			location: "/usr/local/bin",
+			x:        5,
+			expected: "/usr/local",
+			ok:       true,
+		},
+		"last_segment": {
+			path:     "/usr/local/bin",
+			location: "/usr/local/bin",
+			x:        13,
+			expected: "/usr/local/bin",
+			ok:       true,
+		},
+		"root": {
+			path:     "/usr/local/bin",
+			location: "/usr/local/bin",
+			x:        0,
+			expected: "/",
+			ok:       true,
+		},
+		"home": {
+			path:     "/home/user/src/nav",
+			location: "~/src/nav",
+			x:        0,
+			expected: "/home/user",
+			ok:       true,
+		},
+		"abbreviated_prefix": {
+			path:     "/home/user/src/nav",
+			location: "~/src/nav",
+			x:        3,
+			expected: "/home/user/src",
+			ok:       true,
+		},
+		"wide_characters": {
+			path:     "/日本/nav",
+			location: "/日本/nav",
+			x:        4,
+			expected: "/日本",
+			ok:       true,
+		},
+		"past_end": {
+			path:     "/usr/local/bin",
+			location: "/usr/local/bin",
+			x:        14,
+			ok:       false,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(tt *testing.T) {
+			ancestor, ok := locationAncestor(test.path, test.location, test.x)
+			if ok != test.ok {
+				tt.Fatalf("expected ok %t, got %t", test.ok, ok)
+			}
+			if ancestor != test.expected {
+				tt.Fatalf("expected %q, got %q", test.expected, ancestor)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dkaslovsky/nav/internal/shell"
+)
+
+// opener is a command used to open files matching a pattern. The pattern is an extension when it
+// starts with "." (".md"), a MIME type when it contains "/" ("text/*"), and a glob matched against
+// the file name otherwise ("Makefile", "*.tar.gz").
+type opener struct {
+	pattern string
+	command string
+}
+
+func (o *opener) matches(name string, mimeType string) bool {
+	switch {
+	case strings.HasPrefix(o.pattern, ".") && !strings.ContainsAny(o.pattern, "*?["):
+		return strings.EqualFold(filepath.Ext(name), o.pattern)
+	case strings.Contains(o.pattern, "/"):
+		matched, _ := path.Match(o.pattern, mimeType)
+		return matched
+	default:
+		matched, _ := filepath.Match(o.pattern, name)
+		return matched
+	}
+}
+
+// execFinishedMsg is sent when a command run with tea.ExecProcess exits.
+type execFinishedMsg struct {
+	err    error
+	status string
+}
+
+// selectFile opens the file at the provided path, or returns it when nav is used for piping or
+// writing selections to a file.
+func (m *model) selectFile(path string) tea.Cmd {
+	if m.modeSubshell || m.chooseFilesFile != "" {
+		return m.returnPaths([]string{path})
+	}
+	return m.open(path)
+}
+
+// open suspends the program and runs the opener for the file at the provided path.
+func (m *model) open(path string) tea.Cmd {
+	command, err := m.opener(path)
+	if err != nil {
+		m.setError(err, "failed to open file")
+		return nil
+	}
+
+	cmd := shell.Command(command, path)
+	cmd.Dir = m.path
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return execFinishedMsg{err: err, status: "failed to open file"}
+	})
+}
+
+// opener returns the command used to open the file at the provided path. Configured openers are
+// checked in order before falling back to $EDITOR or $PAGER for text files and the system opener
+// for all other files.
+func (m *model) opener(path string) (string, error) {
+	mimeType := detectMimeType(path)
+	for _, o := range m.openers {
+		if o.matches(filepath.Base(path), mimeType) {
+			return o.command, nil
+		}
+	}
+
+	if strings.HasPrefix(mimeType, "text/") {
+		for _, env := range []string{"EDITOR", "PAGER"} {
+			if command := os.Getenv(env); command != "" {
+				return command, nil
+			}
+		}
+	}
+
+	systemOpener := shell.SystemOpener()
+	if _, err := exec.LookPath(strings.Fields(systemOpener)[0]); err != nil {
+		return "", errors.New("no opener found: configure an opener or set $EDITOR or $PAGER")
+	}
+	return systemOpener, nil
+}
+
+// detectMimeType returns the MIME type of a file without parameters, determined from its extension
+// or by sniffing its contents when the extension is not recognized.
+func detectMimeType(path string) string {
+	mimeType := mime.TypeByExtension(filepath.Ext(path))
+	if mimeType == "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return ""
+		}
+		defer f.Close()
+
+		buf := make([]byte, 512)
+		n, _ := f.Read(buf)
+		mimeType = http.DetectContentType(buf[:n])
+	}
+
+	mimeType, _, _ = strings.Cut(mimeType, ";")
+	return strings.TrimSpace(mimeType)
+}
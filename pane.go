@@ -0,0 +1,104 @@
+package main
+
+import "github.com/dkaslovsky/nav/internal/gitstatus"
+
+// pane contains the navigation state of a directory listing.
+type pane struct {
+	path      string
+	prevPath  string
+	entries   []*entry
+	displayed int
+	search    lineEditor
+	git       *gitstatus.Status
+	listed    int                   // Count of directory listings, used to detect changes for background loading.
+	gitListed int                   // Value of listed when the git status was last requested.
+	pathCache map[string]*cacheItem // Map path to cached state.
+	marks     map[string]int        // Map marked path to the order in which it was marked.
+	markCount int                   // Count of marks made, used to order marks.
+	markSort  []string              // Marked paths sorted by type, nil until needed after marks change.
+	focusPath string                // Path of an entry to move the cursor to when next displayed.
+
+	c       int // Cursor column position.
+	r       int // Cursor row position.
+	columns int // Displayed columns.
+	rows    int // Displayed columns.
+
+	columnLens []int // Width of the names in each displayed column.
+
+	modeMarks bool
+}
+
+func newPane() *pane {
+	return &pane{
+		pathCache: make(map[string]*cacheItem),
+		marks:     make(map[string]int),
+		modeMarks: false,
+	}
+}
+
+// inPane calls f with the provided pane as the current pane, restoring the current pane afterwards.
+func (m *model) inPane(p *pane, f func()) {
+	current := m.pane
+	m.pane = p
+	defer func() { m.pane = current }()
+	f()
+}
+
+// paneWidth returns the width available to display a pane.
+func (m *model) paneWidth() int {
+	if m.modeDual {
+		// Account for the space separating the panes.
+		return (m.width - 1) / 2
+	}
+	return m.width
+}
+
+// displayedPanes returns the panes that are displayed.
+func (m *model) displayedPanes() []*pane {
+	if m.modeDual {
+		return m.panes
+	}
+	return []*pane{m.pane}
+}
+
+// otherPane returns the displayed pane without focus, or nil when a single pane is displayed.
+func (m *model) otherPane() *pane {
+	for _, p := range m.displayedPanes() {
+		if p != m.pane {
+			return p
+		}
+	}
+	return nil
+}
+
+func (m *model) toggleDual() error {
+	if m.modeDual {
+		m.modeDual = false
+		return nil
+	}
+	return m.openDual()
+}
+
+// openDual enters dual pane mode, opening a second pane in the current directory the first time.
+func (m *model) openDual() error {
+	if len(m.panes) == 1 {
+		p := newPane()
+		p.path = m.path
+		var err error
+		m.inPane(p, func() { err = m.list() })
+		if err != nil {
+			return err
+		}
+		m.panes = append(m.panes, p)
+	}
+	m.modeDual = true
+	return nil
+}
+
+// switchPane moves focus to the other displayed pane.
+func (m *model) switchPane() {
+	if other := m.otherPane(); other != nil {
+		m.saveCursor()
+		m.pane = other
+	}
+}
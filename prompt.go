@@ -0,0 +1,82 @@
+package main
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// prompt is a single line input that collects a value and submits it to an action.
+type prompt struct {
+	mode       string // Displayed in the status bar.
+	label      string // Displayed before the input in the location bar.
+	input      lineEditor
+	submit     func(m *model, value string) tea.Cmd
+	complete   func(m *model, value string) string // Completes the value, if set.
+	candidates []string                            // Candidates of an ambiguous completion, displayed until the next key.
+	history    *promptHistory
+}
+
+func newPrompt(mode string, label string, submit func(m *model, value string) tea.Cmd) *prompt {
+	return &prompt{
+		mode:   mode,
+		label:  label,
+		submit: submit,
+	}
+}
+
+// promptHistory contains previously submitted prompt values.
+type promptHistory struct {
+	values []string
+	idx    int // Index of the value being displayed, equal to len(values) when at the new input.
+}
+
+func (h *promptHistory) add(value string) {
+	if value != "" && (len(h.values) == 0 || h.values[len(h.values)-1] != value) {
+		h.values = append(h.values, value)
+	}
+	h.idx = len(h.values)
+}
+
+func (h *promptHistory) prev() (string, bool) {
+	if h.idx == 0 {
+		return "", false
+	}
+	h.idx--
+	return h.values[h.idx], true
+}
+
+func (h *promptHistory) next() (string, bool) {
+	if h.idx >= len(h.values) {
+		return "", false
+	}
+	h.idx++
+	if h.idx == len(h.values) {
+		return "", true
+	}
+	return h.values[h.idx], true
+}
+
+// openPrompt enters prompt mode with a history shared by all prompts of the same mode.
+func (m *model) openPrompt(p *prompt) {
+	history, ok := m.promptHistories[p.mode]
+	if !ok {
+		history = &promptHistory{}
+		m.promptHistories[p.mode] = history
+	}
+	history.idx = len(history.values)
+	p.history = history
+
+	m.prompt = p
+	m.modePrompt = true
+}
+
+// submitPrompt closes the prompt and submits a value to its action, adding the value to the
+// prompt's history.
+func (m *model) submitPrompt(value string) tea.Cmd {
+	p := m.prompt
+	p.history.add(value)
+	m.closePrompt()
+	return p.submit(m, value)
+}
+
+func (m *model) closePrompt() {
+	m.prompt = nil
+	m.modePrompt = false
+}
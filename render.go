@@ -7,14 +7,21 @@ import (
 var (
 	cursorRendererNormal         = newCursorRenderer(lipgloss.NewStyle().SetString(" "))
 	cursorRendererMarked         = newCursorRenderer(lipgloss.NewStyle().SetString("+"))
+	cursorRendererVisual         = newCursorRenderer(lipgloss.NewStyle().SetString("~"))
 	cursorRendererSelected       = newCursorRenderer(lipgloss.NewStyle().Bold(true).SetString(">"))
 	cursorRendererSelectedMarked = newCursorRenderer(lipgloss.NewStyle().Bold(true).SetString("+"))
 
-	barRendererLocation = lipgloss.NewStyle().Background(lipgloss.Color("#5C5C5C")).Foreground(lipgloss.Color("#FFFFFF"))
-	barRendererSearch   = lipgloss.NewStyle().Background(lipgloss.Color("#499F1C")).Foreground(lipgloss.Color("#FFFFFF"))
-	barRendererStatus   = lipgloss.NewStyle().Background(lipgloss.Color("#494949")).Foreground(lipgloss.Color("#FFFFFF"))
-	barRendererError    = lipgloss.NewStyle().Background(lipgloss.Color("#EB5B34")).Foreground(lipgloss.Color("#FFFFFF"))
-	barRendererOK       = lipgloss.NewStyle().Background(lipgloss.Color("#499F1C")).Foreground(lipgloss.Color("#FFFFFF"))
+	// Jump labels are rendered in place of the cursor with only the label styled.
+	cursorStyleJump = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#EB5B34"))
+
+	barRendererLocation         = lipgloss.NewStyle().Background(lipgloss.Color("#5C5C5C")).Foreground(lipgloss.Color("#FFFFFF"))
+	barRendererLocationInactive = lipgloss.NewStyle().Background(lipgloss.Color("#3C3C3C")).Foreground(lipgloss.Color("#A0A0A0"))
+	barRendererSearch           = lipgloss.NewStyle().Background(lipgloss.Color("#499F1C")).Foreground(lipgloss.Color("#FFFFFF"))
+	barRendererInfo             = lipgloss.NewStyle().Background(lipgloss.Color("#3C3C3C")).Foreground(lipgloss.Color("#E0A030"))
+	barRendererPrompt           = lipgloss.NewStyle().Background(lipgloss.Color("#1C6F9F")).Foreground(lipgloss.Color("#FFFFFF"))
+	barRendererStatus           = lipgloss.NewStyle().Background(lipgloss.Color("#494949")).Foreground(lipgloss.Color("#FFFFFF"))
+	barRendererError            = lipgloss.NewStyle().Background(lipgloss.Color("#EB5B34")).Foreground(lipgloss.Color("#FFFFFF"))
+	barRendererOK               = lipgloss.NewStyle().Background(lipgloss.Color("#499F1C")).Foreground(lipgloss.Color("#FFFFFF"))
 )
 
 type cursorRenderer struct {
@@ -38,3 +45,9 @@ func newCursorRenderer(style lipgloss.Style) *cursorRenderer {
 func (r *cursorRenderer) Render(name string) string {
 	return r.style.Render(name) + r.pad
 }
+
+// renderJumpLabel renders a name following its jump label, styling only the label.
+func renderJumpLabel(label string, name string) string {
+	r := newCursorRenderer(lipgloss.NewStyle().SetString(label))
+	return cursorStyleJump.Render(label) + " " + name + r.pad
+}
@@ -0,0 +1,71 @@
+package main
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// searchMatches returns the displayed entries of the current directory with names starting with
+// the search.
+func (m *model) searchMatches() []*entry {
+	matches := []*entry{}
+	for _, ent := range m.entries {
+		if ent.depth == 0 && m.filterEntry(ent) == entryFilterNone {
+			matches = append(matches, ent)
+		}
+	}
+	return matches
+}
+
+// completeSearch completes the search as a shell completes a path. The search is extended to the
+// longest prefix shared by the matching entries and, once it cannot be extended, each completion
+// moves the cursor to the next matching entry. A uniquely matched directory is navigated into to
+// continue completing from it.
+func (m *model) completeSearch() tea.Cmd {
+	matches := m.searchMatches()
+	if len(matches) == 0 {
+		return nil
+	}
+
+	if len(matches) == 1 {
+		ent := matches[0]
+		if isDir(ent) {
+			return m.searchSelect(ent)
+		}
+		m.search.set(ent.Name())
+		m.focusPath = ent.path()
+		return nil
+	}
+
+	names := make([]string, len(matches))
+	for i, ent := range matches {
+		names[i] = ent.Name()
+	}
+	if prefix := commonPrefix(names); len(prefix) > len(m.search.value) {
+		m.search.set(prefix)
+		m.focusPath = matches[0].path()
+		return nil
+	}
+
+	// Cycle through the matches, starting after the entry under the cursor.
+	next := matches[0]
+	if selected, err := m.selected(); err == nil {
+		for i, ent := range matches {
+			if ent.path() == selected.path() {
+				next = matches[(i+1)%len(matches)]
+				break
+			}
+		}
+	}
+	m.focusPath = next.path()
+	return nil
+}
+
+// isDir returns whether an entry is a directory or a symlink to a directory.
+func isDir(ent *entry) bool {
+	if ent.hasMode(entryModeDir) {
+		return true
+	}
+	if !ent.hasMode(entryModeSymlink) {
+		return false
+	}
+	sl, err := followSymlink(ent)
+	return err == nil && sl.info.IsDir()
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCompleteSearch(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"docs", "downloads"} {
+		if err := os.Mkdir(filepath.Join(dir, name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"notes", filepath.Join("docs", "readme")} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := newModel()
+	m.setPath(dir)
+	if err := m.list(); err != nil {
+		t.Fatal(err)
+	}
+	m.modeSearch = true
+	m.search.set("d")
+
+	// complete runs a completion and renders the result, as the program does after each key.
+	complete := func() {
+		m.completeSearch()
+		m.normalView()
+	}
+	expectSelected := func(name string) {
+		selected, err := m.selected()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if selected.Name() != name {
+			t.Fatalf("expected cursor on %q, got %q", name, selected.Name())
+		}
+	}
+
+	// Extend to the common prefix.
+	complete()
+	if m.search.value != "do" {
+		t.Fatalf("expected search to extend to %q, got %q", "do", m.search.value)
+	}
+	expectSelected("docs")
+
+	// Cycle through the matches.
+	complete()
+	expectSelected("downloads")
+	complete()
+	expectSelected("docs")
+	if m.search.value != "do" {
+		t.Fatalf("expected search to remain %q while cycling, got %q", "do", m.search.value)
+	}
+
+	// Enter a uniquely matched directory.
+	m.search.set("doc")
+	complete()
+	if m.path != filepath.Join(dir, "docs") || m.search.value != "" {
+		t.Fatalf("expected to enter docs with an empty search, got %q with search %q", m.path, m.search.value)
+	}
+
+	// Complete a uniquely matched file without selecting it.
+	complete()
+	if m.search.value != "readme" || m.path != filepath.Join(dir, "docs") {
+		t.Fatalf("expected search to complete to %q, got %q", "readme", m.search.value)
+	}
+}
+
+func TestSearchGridKeys(t *testing.T) {
+	m := newModel()
+	m.rows, m.columns, m.displayed = 4, 2, 8
+	m.modeSearch = true
+	m.search.set("ab")
+	m.search.cursor = 1
+	m.setCursor(&position{c: 1, r: 2})
+
+	// The arrows move the grid cursor rather than the search cursor.
+	m.update(tea.KeyMsg{Type: tea.KeyLeft})
+	if m.c != 0 || m.r != 2 {
+		t.Fatalf("expected grid cursor at (0, 2), got (%d, %d)", m.c, m.r)
+	}
+	if m.search.cursor != 1 {
+		t.Fatalf("expected search cursor at offset 1, got %d", m.search.cursor)
+	}
+
+	m.update(tea.KeyMsg{Type: tea.KeyCtrlB})
+	if m.search.cursor != 0 || m.c != 0 {
+		t.Fatalf("expected search cursor at offset 0 and grid column 0, got %d and %d", m.search.cursor, m.c)
+	}
+}
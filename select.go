@@ -2,12 +2,10 @@ package main
 
 import (
 	"errors"
-	"fmt"
 	"path/filepath"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/dkaslovsky/nav/internal/sanitize"
 )
 
 func (m *model) selectAction() (*model, tea.Cmd) {
@@ -20,29 +18,21 @@ func (m *model) selectAction() (*model, tea.Cmd) {
 	m.saveCursor()
 
 	if selected.hasMode(entryModeFile) {
-		m.setExit(sanitize.SanitizeOutputPath(filepath.Join(m.path, selected.Name())))
-		if m.modeSubshell {
-			fmt.Print(m.exitStr)
-		}
-		return m, tea.Quit
+		return m, m.selectFile(selected.path())
 	}
 	if selected.hasMode(entryModeSymlink) {
-		sl, err := followSymlink(m.path, selected)
+		sl, err := followSymlink(selected)
 		if err != nil {
 			m.setError(err, "failed to evaluate symlink")
 			return m, nil
 		}
 		if !sl.info.IsDir() {
 			// The symlink points to a file.
-			m.setExit(sanitize.SanitizeOutputPath(sl.absPath))
-			if m.modeSubshell {
-				fmt.Print(m.exitStr)
-			}
-			return m, tea.Quit
+			return m, m.selectFile(sl.absPath)
 		}
 		m.setPath(sl.absPath)
 	} else if selected.hasMode(entryModeDir) {
-		path, err := filepath.Abs(filepath.Join(m.path, selected.Name()))
+		path, err := filepath.Abs(selected.path())
 		if err != nil {
 			m.setError(err, "failed to evaluate path")
 			return m, nil
@@ -69,6 +59,41 @@ func (m *model) selectAction() (*model, tea.Cmd) {
 	return m, nil
 }
 
+func (m *model) backAction() (*model, tea.Cmd) {
+	m.saveCursor()
+
+	path, err := filepath.Abs(filepath.Join(m.path, ".."))
+	if err != nil {
+		m.setError(err, "failed to evaluate path")
+		return m, nil
+	}
+	m.setPath(path)
+
+	err = m.list()
+	if err != nil {
+		m.restorePath()
+		m.setError(err, err.Error())
+		return m, nil
+	}
+
+	m.clearSearch()
+
+	// Return to ensure the cursor is not re-saved using the updated path.
+	return m, nil
+}
+
+// changeDirectory navigates to the directory at path.
+func (m *model) changeDirectory(path string) error {
+	m.saveCursor()
+	m.setPath(path)
+	if err := m.list(); err != nil {
+		m.restorePath()
+		return err
+	}
+	m.clearSearch()
+	return nil
+}
+
 func (m *model) searchSelectAction() (*model, tea.Cmd) {
 	selected, err := m.selected()
 	if err != nil {
@@ -76,37 +101,33 @@ func (m *model) searchSelectAction() (*model, tea.Cmd) {
 		m.clearSearch()
 		return m, nil
 	}
+	return m, m.searchSelect(selected)
+}
 
+// searchSelect navigates into a directory or selects a file matched by the search.
+func (m *model) searchSelect(selected *entry) tea.Cmd {
 	if selected.hasMode(entryModeFile) {
-		m.setExit(sanitize.SanitizeOutputPath(filepath.Join(m.path, selected.Name())))
-		if m.modeSubshell {
-			fmt.Print(m.exitStr)
-		}
-		return m, tea.Quit
+		return m.selectFile(selected.path())
 	}
 	if selected.hasMode(entryModeSymlink) {
-		sl, err := followSymlink(m.path, selected)
+		sl, err := followSymlink(selected)
 		if err != nil {
 			m.setError(err, "failed to evaluate symlink")
-			return m, nil
+			return nil
 		}
 		if !sl.info.IsDir() {
 			// The symlink points to a file.
-			m.setExit(sanitize.SanitizeOutputPath(sl.absPath))
-			if m.modeSubshell {
-				fmt.Print(m.exitStr)
-			}
-			return m, tea.Quit
+			return m.selectFile(sl.absPath)
 		}
 		m.setPath(sl.absPath)
 	} else if selected.hasMode(entryModeDir) {
-		m.setPath(m.path + fileSeparator + selected.Name())
+		m.setPath(selected.dir + fileSeparator + selected.Name())
 	} else {
 		m.setError(
 			errors.New("selection is not a file, directory, or symlink"),
 			"unexpected file type",
 		)
-		return m, nil
+		return nil
 	}
 
 	// Trim repeated leading file separator characters that occur from searching back
@@ -115,13 +136,11 @@ func (m *model) searchSelectAction() (*model, tea.Cmd) {
 		m.path = m.path[1:]
 	}
 
-	m.search = ""
-	err = m.list()
-	if err != nil {
+	m.search.set("")
+	if err := m.list(); err != nil {
 		m.restorePath()
 		m.setError(err, err.Error())
 		m.clearSearch()
-		return m, nil
 	}
-	return m, nil
+	return nil
 }
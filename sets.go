@@ -0,0 +1,416 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const envSets = "NAV_SETS"
+
+// setsDir returns the directory of saved selection sets, which can be overridden by setting the
+// NAV_SETS environment variable.
+func setsDir() (string, error) {
+	if dir := os.Getenv(envSets); dir != "" {
+		return dir, nil
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, name, "sets"), nil
+}
+
+// setPath returns the path of the file storing a selection set.
+func setPath(setName string) (string, error) {
+	if setName == "" || strings.HasPrefix(setName, ".") || strings.ContainsAny(setName, `/\`) {
+		return "", fmt.Errorf("invalid set name %q", setName)
+	}
+	dir, err := setsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, setName), nil
+}
+
+// saveSet writes the paths of a selection set, one per line, replacing any existing set with the
+// same name.
+func saveSet(setName string, paths []string) error {
+	path, err := setPath(setName)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	content := ""
+	if len(paths) > 0 {
+		content = strings.Join(paths, "\n") + "\n"
+	}
+	return os.WriteFile(path, []byte(content), 0o600)
+}
+
+// loadSet reads the paths of a selection set.
+func loadSet(setName string) ([]string, error) {
+	path, err := setPath(setName)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("set %q does not exist", setName)
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	paths := []string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			paths = append(paths, line)
+		}
+	}
+	return paths, scanner.Err()
+}
+
+// listSets returns the names of the saved selection sets in sorted order.
+func listSets() ([]string, error) {
+	dir, err := setsDir()
+	if err != nil {
+		return nil, err
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	names := []string{}
+	for _, file := range files {
+		if !file.IsDir() && !strings.HasPrefix(file.Name(), ".") {
+			names = append(names, file.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func deleteSet(setName string) error {
+	path, err := setPath(setName)
+	if err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+// setInfo contains the number of paths in a selection set and the number that no longer exist.
+type setInfo struct {
+	paths int
+	stale int
+}
+
+// loadSetInfo counts the paths of a selection set and the paths that no longer exist.
+func loadSetInfo(setName string) (*setInfo, error) {
+	paths, err := loadSet(setName)
+	if err != nil {
+		return nil, err
+	}
+	_, stale := existingPaths(paths)
+	return &setInfo{paths: len(paths), stale: len(stale)}, nil
+}
+
+// existingPaths splits paths into those that exist and those that no longer exist.
+func existingPaths(paths []string) (existing []string, stale []string) {
+	for _, path := range paths {
+		if _, err := os.Lstat(path); err != nil {
+			stale = append(stale, path)
+		} else {
+			existing = append(existing, path)
+		}
+	}
+	return existing, stale
+}
+
+func (m *model) openSaveSetPrompt() {
+	m.openPrompt(newPrompt("SAVE SET", "save set: ", func(m *model, value string) tea.Cmd {
+		if !m.modeMarks {
+			m.setError(errors.New("no marked entries"), "failed to save set")
+			return nil
+		}
+		if err := saveSet(strings.TrimSpace(value), m.markedPaths()); err != nil {
+			m.setError(err, "failed to save set")
+		}
+		return nil
+	}))
+}
+
+// openSets enters the sets view listing the saved selection sets.
+func (m *model) openSets() error {
+	names, err := listSets()
+	if err != nil {
+		return err
+	}
+	m.modeSets = true
+	m.sets = names
+	m.setsInfo = make([]*setInfo, len(names))
+	for i, setName := range names {
+		if info, err := loadSetInfo(setName); err == nil {
+			m.setsInfo[i] = info
+		}
+	}
+	m.setsCursor = 0
+	m.setName = ""
+	return nil
+}
+
+// openSet displays the paths of a saved selection set for editing.
+func (m *model) openSet(setName string) error {
+	paths, err := loadSet(setName)
+	if err != nil {
+		return err
+	}
+	m.setName = setName
+	m.setPaths = paths
+	m.setStale = stalePaths(paths)
+	m.setsCursor = 0
+	return nil
+}
+
+// stalePaths returns whether each path no longer exists.
+func stalePaths(paths []string) []bool {
+	stale := make([]bool, len(paths))
+	for i, path := range paths {
+		_, err := os.Lstat(path)
+		stale[i] = err != nil
+	}
+	return stale
+}
+
+// markSet marks the existing paths of a saved selection set.
+func (m *model) markSet(setName string) error {
+	paths, err := loadSet(setName)
+	if err != nil {
+		return err
+	}
+	existing, _ := existingPaths(paths)
+	for _, path := range existing {
+		if _, marked := m.marks[path]; !marked {
+			m.mark(path)
+		}
+	}
+	return nil
+}
+
+// setsItems returns the items displayed in the sets view, which are the set names or the paths of
+// the opened set.
+func (m *model) setsItems() []string {
+	if m.setName != "" {
+		return m.setPaths
+	}
+	return m.sets
+}
+
+func (m *model) setsSelected() (string, bool) {
+	items := m.setsItems()
+	if m.setsCursor < 0 || m.setsCursor >= len(items) {
+		return "", false
+	}
+	return items[m.setsCursor], true
+}
+
+// setsView lists the saved selection sets with their sizes and stale path counts, or the paths
+// of the opened set with stale paths flagged.
+func (m *model) setsView() string {
+	var locationBar string
+	if m.setName != "" {
+		locationBar = barRendererLocation.Render(fmt.Sprintf("set: %s", m.setName))
+	} else {
+		locationBar = barRendererLocation.Render("selection sets")
+	}
+
+	items := m.setsItems()
+	if len(items) == 0 {
+		if m.setName != "" {
+			return locationBar + "\n\n\t(no paths)\n"
+		}
+		return locationBar + "\n\n\t(no saved sets)\n"
+	}
+	if m.setsCursor >= len(items) {
+		m.setsCursor = len(items) - 1
+	}
+	if m.setsCursor < 0 {
+		m.setsCursor = 0
+	}
+
+	// Scroll to keep the cursor visible.
+	height := m.viewHeight()
+	start := 0
+	if m.setsCursor >= height {
+		start = m.setsCursor - height + 1
+	}
+
+	output := []string{locationBar}
+	for i := start; i < len(items) && i < start+height; i++ {
+		var name string
+		if m.setName != "" {
+			name = displayPath(items[i])
+			if m.setStale[i] {
+				name += fmt.Sprintf(" %s(missing)%s", colorRed, colorReset)
+			}
+		} else {
+			name = items[i]
+			if info := m.setsInfo[i]; info != nil {
+				name += fmt.Sprintf(" (%d paths", info.paths)
+				if info.stale > 0 {
+					name += fmt.Sprintf(", %s%d missing%s", colorRed, info.stale, colorReset)
+				}
+				name += ")"
+			}
+		}
+
+		if i == m.setsCursor {
+			output = append(output, cursorRendererSelected.Render(name))
+		} else {
+			output = append(output, cursorRendererNormal.Render(name))
+		}
+	}
+	return strings.Join(output, "\n")
+}
+
+func actionModeSets(m *model, msg tea.KeyMsg, esc bool) actionResult {
+	if m.setName != "" {
+		return actionModeSetPaths(m, msg, esc)
+	}
+
+	switch {
+
+	case esc || key.Matches(msg, keyEsc) || key.Matches(msg, keySets):
+		m.modeSets = false
+
+	case key.Matches(msg, keyUp):
+		m.setsCursor--
+
+	case key.Matches(msg, keyDown):
+		m.setsCursor++
+
+	case key.Matches(msg, keySelect):
+		if setName, ok := m.setsSelected(); ok {
+			if err := m.markSet(setName); err != nil {
+				m.setError(err, "failed to load set")
+				return newActionResult(nil)
+			}
+			m.modeSets = false
+		}
+
+	case key.Matches(msg, keyRight):
+		if setName, ok := m.setsSelected(); ok {
+			if err := m.openSet(setName); err != nil {
+				m.setError(err, "failed to open set")
+			}
+		}
+
+	case key.Matches(msg, keySetDelete):
+		if setName, ok := m.setsSelected(); ok {
+			if err := deleteSet(setName); err != nil {
+				m.setError(err, "failed to delete set")
+				return newActionResult(nil)
+			}
+			if err := m.openSets(); err != nil {
+				m.setError(err, "failed to list sets")
+			}
+		}
+
+	// Allow returning from the sets view.
+	case key.Matches(msg, keyReturnSelected), key.Matches(msg, keyReturnDirectory):
+		return newActionResultNoop()
+
+	}
+
+	// Unconditional return to disable all other functionality.
+	return newActionResult(nil)
+}
+
+func actionModeSetPaths(m *model, msg tea.KeyMsg, esc bool) actionResult {
+	switch {
+
+	case esc || key.Matches(msg, keyEsc) || key.Matches(msg, keyLeft):
+		if err := m.openSets(); err != nil {
+			m.setError(err, "failed to list sets")
+		}
+
+	case key.Matches(msg, keyUp):
+		m.setsCursor--
+
+	case key.Matches(msg, keyDown):
+		m.setsCursor++
+
+	case key.Matches(msg, keySelect):
+		if path, ok := m.setsSelected(); ok {
+			if err := m.goToPath(path); err != nil {
+				m.setError(err, err.Error())
+				return newActionResult(nil)
+			}
+			m.modeSets = false
+			// Return to ensure the cursor is not re-saved using the updated path.
+			return newActionResult(nil)
+		}
+
+	case key.Matches(msg, keySetDelete):
+		if _, ok := m.setsSelected(); ok {
+			paths := append([]string{}, m.setPaths[:m.setsCursor]...)
+			m.saveSetPaths(append(paths, m.setPaths[m.setsCursor+1:]...))
+		}
+
+	case key.Matches(msg, keySetPrune):
+		existing, _ := existingPaths(m.setPaths)
+		m.saveSetPaths(existing)
+
+	case key.Matches(msg, keySetAdd):
+		paths := append([]string{}, m.setPaths...)
+		for _, path := range m.markedPaths() {
+			if !containsString(paths, path) {
+				paths = append(paths, path)
+			}
+		}
+		m.saveSetPaths(paths)
+
+	// Allow returning from the sets view.
+	case key.Matches(msg, keyReturnSelected), key.Matches(msg, keyReturnDirectory):
+		return newActionResultNoop()
+
+	}
+
+	// Unconditional return to disable all other functionality.
+	return newActionResult(nil)
+}
+
+// saveSetPaths saves the paths of the opened set.
+func (m *model) saveSetPaths(paths []string) {
+	if err := saveSet(m.setName, paths); err != nil {
+		m.setError(err, "failed to save set")
+		return
+	}
+	m.setPaths = paths
+	m.setStale = stalePaths(paths)
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSaveLoadSet(t *testing.T) {
+	t.Setenv(envSets, t.TempDir())
+
+	paths := []string{"/tmp/b", "/tmp/a b"}
+	if err := saveSet("manifests", paths); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := saveSet("empty", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loaded, err := loadSet("manifests")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, paths) {
+		t.Fatalf("expected %v, got %v", paths, loaded)
+	}
+
+	names, err := listSets()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"empty", "manifests"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("expected %v, got %v", want, names)
+	}
+
+	if _, err := loadSet("missing"); err == nil {
+		t.Fatal("expected error for missing set")
+	}
+}
+
+func TestSetPathInvalidNames(t *testing.T) {
+	t.Setenv(envSets, t.TempDir())
+
+	for _, setName := range []string{"", ".hidden", "a/b", `a\b`} {
+		if _, err := setPath(setName); err == nil {
+			t.Errorf("expected error for set name %q", setName)
+		}
+	}
+}
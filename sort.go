@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// sortMode is the ordering of entries within each type (mode).
+type sortMode int
+
+const (
+	sortModeName sortMode = iota
+	sortModeSize
+	sortModeTime
+)
+
+var sortModeNames = []string{"name", "size", "time"}
+
+func (s sortMode) String() string {
+	return sortModeNames[s]
+}
+
+func (s sortMode) next() sortMode {
+	return (s + 1) % sortMode(len(sortModeNames))
+}
+
+func parseSortMode(name string) (sortMode, error) {
+	if strings.EqualFold(name, "mtime") {
+		name = sortModeNames[sortModeTime]
+	}
+	for i, n := range sortModeNames {
+		if strings.EqualFold(name, n) {
+			return sortMode(i), nil
+		}
+	}
+	return sortModeName, fmt.Errorf("invalid sort mode %q, must be one of: %s", name, strings.Join(sortModeNames, ", "))
+}
+
+// entryLess returns the less function for ordering entries by the current sort mode. Sizes and
+// modification times are ordered largest and newest first with ties ordered by name.
+func (m *model) entryLess() func(iEntry, jEntry *entry) bool {
+	switch m.sortMode {
+	case sortModeSize:
+		return func(iEntry, jEntry *entry) bool {
+			iSize, jSize := m.entrySize(iEntry), m.entrySize(jEntry)
+			if iSize != jSize {
+				return iSize > jSize
+			}
+			return iEntry.Name() < jEntry.Name()
+		}
+	case sortModeTime:
+		return func(iEntry, jEntry *entry) bool {
+			iTime, jTime := iEntry.info.ModTime(), jEntry.info.ModTime()
+			if !iTime.Equal(jTime) {
+				return iTime.After(jTime)
+			}
+			return iEntry.Name() < jEntry.Name()
+		}
+	default:
+		return func(iEntry, jEntry *entry) bool {
+			return iEntry.Name() < jEntry.Name()
+		}
+	}
+}
+
+// entrySize returns the size of an entry, using the computed total size for directories when
+// available.
+func (m *model) entrySize(ent *entry) int64 {
+	if size, ok := m.dirSize(ent.path(), ent.info); ok {
+		return size
+	}
+	return ent.info.Size()
+}
+
+// sortEntries performs an in-place sort of a slice of entries in the current directory by the
+// current sort mode or by disk usage in disk usage mode.
+func (m *model) sortEntries(entries []*entry) {
+	if m.modeDiskUsage {
+		m.sortDiskUsage(entries)
+		return
+	}
+	sortEntriesWith(entries, m.entryLess())
+}
+
+// sort re-sorts the entries by the current sort mode, remapping the cached cursor position to the
+// new entry indexes.
+func (m *model) sort() {
+	prev := m.entries
+	m.entries = m.sortTree(m.entries)
+	m.remapEntries(prev)
+}
@@ -0,0 +1,17 @@
+package main
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// busy reports whether any background computations shown with the spinner are in progress.
+func (m *model) busy() bool {
+	return len(m.dirSizesPending) > 0 || m.diskUsageScanning != ""
+}
+
+// spinnerCmd starts the spinner ticking when background computations are in progress.
+func (m *model) spinnerCmd() tea.Cmd {
+	if !m.busy() || m.spinning {
+		return nil
+	}
+	m.spinning = true
+	return m.spinner.Tick
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// tab contains the panes of an independent navigation state.
+type tab struct {
+	*pane         // Pane with focus, or the pane being listed or displayed.
+	panes []*pane // Panes displayed side by side in dual pane mode.
+
+	modeDual bool
+}
+
+func newTab(path string) *tab {
+	p := newPane()
+	p.path = path
+	return &tab{
+		pane:     p,
+		panes:    []*pane{p},
+		modeDual: false,
+	}
+}
+
+// listTabs lists the directories of all tabs.
+func (m *model) listTabs() error {
+	active := m.tab
+	defer func() { m.tab = active }()
+
+	for _, t := range m.tabs {
+		m.tab = t
+		if err := m.listTab(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listTab lists the directory of the active tab, opening its second pane in dual pane mode.
+func (m *model) listTab() error {
+	if err := m.list(); err != nil {
+		return err
+	}
+	if m.modeDual {
+		return m.openDual()
+	}
+	return nil
+}
+
+// openTab opens a new tab in the current directory after the active tab and moves to it.
+func (m *model) openTab() error {
+	t := newTab(m.path)
+	t.modeDual = m.modeDual
+
+	m.saveCursor()
+	active := m.tab
+	m.tab = t
+	if err := m.listTab(); err != nil {
+		m.tab = active
+		return err
+	}
+
+	idx := indexOfTab(m.tabs, active) + 1
+	m.tabs = append(m.tabs[:idx], append([]*tab{t}, m.tabs[idx:]...)...)
+	return nil
+}
+
+// closeTab closes the active tab and moves to the next tab, or the previous tab when closing the
+// last tab. The only tab cannot be closed.
+func (m *model) closeTab() {
+	if len(m.tabs) == 1 {
+		return
+	}
+	idx := m.tabIndex()
+	m.tabs = append(m.tabs[:idx], m.tabs[idx+1:]...)
+	if idx == len(m.tabs) {
+		idx--
+	}
+	m.tab = m.tabs[idx]
+}
+
+// cycleTab moves to the tab offset from the active tab, wrapping around at either end.
+func (m *model) cycleTab(offset int) {
+	m.saveCursor()
+	idx := (m.tabIndex() + offset + len(m.tabs)) % len(m.tabs)
+	m.tab = m.tabs[idx]
+}
+
+func (m *model) tabIndex() int {
+	return indexOfTab(m.tabs, m.tab)
+}
+
+func indexOfTab(tabs []*tab, t *tab) int {
+	for i, tt := range tabs {
+		if tt == t {
+			return i
+		}
+	}
+	return 0
+}
+
+// tabBar displays the tabs by number and directory name, highlighting the active tab.
+func (m *model) tabBar() string {
+	labels := []string{}
+	for i, t := range m.tabs {
+		label := fmt.Sprintf(" %d:%s ", i+1, filepath.Base(t.path))
+		if t == m.tab {
+			labels = append(labels, barRendererLocation.Render(label))
+		} else {
+			labels = append(labels, barRendererLocationInactive.Render(label))
+		}
+	}
+	return strings.Join(labels, " ")
+}
+
+// showTabBar reports whether the tab bar is displayed, which is only when multiple tabs are open.
+func (m *model) showTabBar() bool {
+	return len(m.tabs) > 1
+}
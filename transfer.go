@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dkaslovsky/nav/internal/fileops"
+)
+
+func (m *model) openCopyPrompt() {
+	m.openTransferPrompt("COPY", "copy to: ", fileops.Copy, "failed to copy")
+}
+
+func (m *model) openMovePrompt() {
+	m.openTransferPrompt("MOVE", "move to: ", fileops.Move, "failed to move")
+}
+
+// openTransferPrompt opens a prompt for the destination of the marked entries, or the entry under
+// the cursor if none are marked. The destination defaults to the directory of the other pane in
+// dual pane mode and to the current directory otherwise.
+func (m *model) openTransferPrompt(
+	mode string,
+	label string,
+	transfer func(src string, dst string) error,
+	status string,
+) {
+	dest := m.path
+	if other := m.otherPane(); other != nil {
+		dest = other.path
+	}
+
+	p := newPrompt(mode, label, func(m *model, value string) tea.Cmd {
+		if err := m.transfer(value, transfer); err != nil {
+			m.setError(err, status)
+		}
+		return nil
+	})
+	p.input.set(dest)
+	m.openPrompt(p)
+}
+
+// transfer copies or moves the marked entries, or the entry under the cursor if none are marked,
+// into the destination directory. A single entry is renamed to the destination when it is not an
+// existing directory.
+func (m *model) transfer(dest string, transfer func(src string, dst string) error) error {
+	dest = strings.TrimSpace(dest)
+	if dest == "" {
+		return nil
+	}
+	if !filepath.IsAbs(dest) {
+		dest = filepath.Join(m.path, dest)
+	}
+
+	srcs := m.markedPaths()
+	if len(srcs) == 0 {
+		selected, err := m.selected()
+		if err != nil {
+			return err
+		}
+		srcs = []string{selected.path()}
+	}
+
+	info, err := os.Stat(dest)
+	destDir := err == nil && info.IsDir()
+	if !destDir && len(srcs) > 1 {
+		return errors.New("destination must be a directory when copying or moving multiple entries")
+	}
+
+	// Reload the displayed panes to reflect the changes, including partial changes from errors.
+	defer m.relistPanes()
+
+	for _, src := range srcs {
+		dst := dest
+		if destDir {
+			dst = filepath.Join(dest, filepath.Base(src))
+		}
+		if err := transfer(src, dst); err != nil {
+			return err
+		}
+	}
+	m.clearMarks()
+	return nil
+}
+
+// relistPanes lists the directories of all displayed panes again.
+func (m *model) relistPanes() {
+	m.previews = make(map[string]*preview)
+	for _, p := range m.displayedPanes() {
+		m.inPane(p, func() {
+			if err := m.relist(); err != nil {
+				m.setError(err, err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import "strings"
+
+const (
+	treeGuideBranch = "├── "
+	treeGuideLast   = "└── "
+	treeGuideLine   = "│   "
+	treeGuideSpace  = "    "
+)
+
+// expanded reports whether the directory at path is expanded in the tree of the current directory.
+func (m *model) expanded(path string) bool {
+	cache, ok := m.pathCache[m.path]
+	return ok && cache.expanded[path]
+}
+
+// setExpanded expands or collapses the directory at path in the tree of the current directory.
+func (m *model) setExpanded(path string, expanded bool) {
+	m.saveCursor()
+	if expanded {
+		m.pathCache[m.path].expanded[path] = true
+		return
+	}
+	delete(m.pathCache[m.path].expanded, path)
+}
+
+// expand expands the directory under the cursor.
+func (m *model) expand() error {
+	selected, err := m.selected()
+	if err != nil {
+		return err
+	}
+	if !selected.hasMode(entryModeDir) || m.expanded(selected.path()) {
+		return nil
+	}
+	m.setExpanded(selected.path(), true)
+	return m.relist()
+}
+
+// collapse collapses the directory under the cursor or, if the cursor is not on an expanded
+// directory, collapses the directory containing the entry under the cursor and moves the cursor to
+// it.
+func (m *model) collapse() error {
+	selected, err := m.selected()
+	if err != nil {
+		return err
+	}
+	path := selected.path()
+	if !m.expanded(path) {
+		if selected.depth == 0 {
+			return nil
+		}
+		path = selected.dir
+		m.focusPath = path
+	}
+	m.setExpanded(path, false)
+	return m.relist()
+}
+
+// sortTree sorts the entries of a tree listing, in which the entries of expanded directories
+// follow their directory with greater depth, keeping each directory's entries together.
+func (m *model) sortTree(entries []*entry) []*entry {
+	roots := []*entry{}
+	children := make(map[*entry][]*entry)
+
+	parents := []*entry{} // Most recent entry at each depth.
+	for _, ent := range entries {
+		parents = append(parents[:ent.depth], ent)
+		if ent.depth == 0 {
+			roots = append(roots, ent)
+			continue
+		}
+		parent := parents[ent.depth-1]
+		children[parent] = append(children[parent], ent)
+	}
+
+	sorted := make([]*entry, 0, len(entries))
+	var flatten func(siblings []*entry)
+	flatten = func(siblings []*entry) {
+		m.sortEntries(siblings)
+		for _, ent := range siblings {
+			sorted = append(sorted, ent)
+			flatten(children[ent])
+		}
+	}
+	flatten(roots)
+	return sorted
+}
+
+// treeGuides returns the indentation guides for displayed tree entries. Entries are expected to be
+// in tree order with every entry's directory displayed before it.
+func treeGuides(entries []*entry) []string {
+	// Determine if each entry is the last displayed entry of its directory.
+	isLast := make([]bool, len(entries))
+	hasLater := []bool{} // Whether a later entry exists at each depth within the current directory.
+	for i := len(entries) - 1; i >= 0; i-- {
+		depth := entries[i].depth
+		for len(hasLater) <= depth {
+			hasLater = append(hasLater, false)
+		}
+		isLast[i] = !hasLater[depth]
+		hasLater[depth] = true
+		hasLater = hasLater[:depth+1]
+	}
+
+	guides := make([]string, len(entries))
+	ancestorsLast := []bool{} // Whether the ancestor of the current entry at each depth is last.
+	for i, ent := range entries {
+		depth := ent.depth
+		if depth > len(ancestorsLast) {
+			depth = len(ancestorsLast)
+		}
+
+		var b strings.Builder
+		for d := 1; d < depth; d++ {
+			if ancestorsLast[d] {
+				b.WriteString(treeGuideSpace)
+			} else {
+				b.WriteString(treeGuideLine)
+			}
+		}
+		if depth > 0 {
+			if isLast[i] {
+				b.WriteString(treeGuideLast)
+			} else {
+				b.WriteString(treeGuideBranch)
+			}
+		}
+		guides[i] = b.String()
+		ancestorsLast = append(ancestorsLast[:depth], isLast[i])
+	}
+	return guides
+}
@@ -41,28 +41,77 @@ func commands() string {
 
 	Arrow keys are used to move the cursor.
 	Vim navigation is available using "h" (left), "j" (down) "k" (up), and "l" (right).
+	Motions can be preceded by a count, such as "5j" to move down five entries.
+	Search and prompt input is edited with "ctrl+b", "ctrl+f", "alt+b", "alt+f", "home", "end",
+	"ctrl+e", "ctrl+w" (delete word), and "ctrl+u" (clear). The prompt also accepts "left",
+	"right", and "ctrl+a", which move the cursor and mark all entries while searching.
 
 %s
 `
 	cmds := []string{
-		usageKeyLine("navigates into the directory or returns the\npath to the entry under the cursor", keySelect),
+		usageKeyLine("navigates into the directory or opens the file under the cursor,\nreturning the path instead with --pipe or --choosefiles", keySelect),
 		usageKeyLine("navigates back to the previous directory", keyBack),
 		"",
+		usageKeyLine("labels the displayed entries, moving to the entry whose label is typed", keyJump),
+		usageKeyLine("pressed twice moves to the first entry, or to the entry numbered by a\ncount", keyFirst),
+		usageKeyLine("moves to the last entry, or to the entry numbered by a count", keyLast),
+		usageKeyLine("moves to the first entry in the row", keyRowStart),
+		usageKeyLine("moves to the last entry in the row", keyRowEnd),
+		usageKeyLine("moves to the top of the column", keyScreenTop),
+		usageKeyLine("moves to the middle of the column", keyScreenMiddle),
+		usageKeyLine("moves to the bottom of the column", keyScreenBottom),
+		usageKeyLine("moves to the top of the column, or of the previous column if already\nat the top", keyColumnPrev),
+		usageKeyLine("moves to the top of the next column", keyColumnNext),
+		usageKeyLine("moves forward by a page", keyPageDown),
+		usageKeyLine("moves back by a page", keyPageUp),
+		"",
 		usageKeyLine("returns the path(s) to the current entry or all marked entries", keyReturnSelected),
 		usageKeyLine("returns the path to the current directory", keyReturnDirectory),
+		usageKeyLine("copies the path(s) to the current entry or all marked entries to the\nclipboard", keyYankSelected),
+		usageKeyLine("copies the path to the current directory to the clipboard", keyYankDirectory),
 		"",
 		usageKeyLine("enters search mode (insert into the path)", keyModeSearch),
 		usageKeyLine("enters debug mode (error details) for errors, otherwise as above", keyModeDebug),
+		usageKeyLine("completes the search to the longest prefix of the matching entries in\nsearch mode, then cycles through them, entering a uniquely matched directory", keyTab),
 		usageKeyLine("enters help mode", keyModeHelp),
+		usageKeyLine("opens the command line to run a named command, such as :cd or :set", keyExCommand),
+		usageKeyLine("opens a prompt to go to a path, such as ~/src or $GOPATH/bin", keyGoTo),
+		usageKeyLine("runs a shell command in the current directory, expanding %f (entry\nunder the cursor), %F (marked entries), and %d (current directory)", keyShellCommand),
+		usageKeyLine("starts a shell in the current directory, returning to nav on exit", keySubshell),
+		usageKeyLine("copies the entry under the cursor or all marked entries, defaulting to\nthe directory of the other pane in dual pane mode", keyCopy),
+		usageKeyLine("moves the entry under the cursor or all marked entries, defaulting to\nthe directory of the other pane in dual pane mode", keyMove),
 		usageKeyLine("switches back to normal mode or clears search filter in normal mode", keyEsc),
 		"",
 		usageKeyLine("(un)marks an entry for multiselect return", keyMark),
 		usageKeyLine("(un)marks all entries for multiselect return", keyMarkAll),
+		usageKeyLine("enters visual mode to select a range of entries with the cursor, which\nare marked with \"v\" or \"enter\" or have their marks toggled with \"ctrl+v\"", keyVisual),
+		usageKeyLine("marks entries with names matching a glob pattern, or a regular\nexpression enclosed in slashes such as /^test/", keyMarkPattern),
+		usageKeyLine("unmarks entries with names matching a pattern as above", keyUnmarkPattern),
+		usageKeyLine("inverts the marks of all entries", keyInvertMarks),
+		usageKeyLine("followed by \"/\", \"*\", or \"@\" marks all directories, executables, or symlinks", keyMarkType),
+		usageKeyLine("opens the selection basket listing marked entries from all directories", keyBasket),
+		usageKeyLine("saves the marked entries as a named selection set", keySaveSet),
+		usageKeyLine("opens the saved selection sets to mark or edit", keySets),
 		"",
 		usageKeyLine("toggles showing hidden files (ls -a)", keyToggleHidden),
 		usageKeyLine("toggles listing full file information (ls -l)", keyToggleList),
+		usageKeyLine("toggles hiding files ignored by git", keyToggleGitignore),
+		usageKeyLine("toggles computing total directory sizes", keyToggleDirSizes),
+		usageKeyLine("cycles sorting by name, size, and modification time", keySort),
+		usageKeyLine("toggles disk usage mode (ncdu), sorting by cumulative size", keyToggleDiskUsage),
+		usageKeyLine("rescans disk usage from the current directory", keyRescanDiskUsage),
+		usageKeyLine("toggles tree mode, in which right (l) expands and left (h) collapses\na directory", keyToggleTree),
+		usageKeyLine("toggles columns mode, showing the parent directory and the contents of\nthe directory under the cursor, in which right (l) and left (h) navigate", keyToggleColumns),
 		usageKeyLine("toggles following symlinks", keyToggleFollowSymlink),
 		"",
+		usageKeyLine("toggles dual pane mode, displaying two independent panes side by side", keyToggleDual),
+		usageKeyLine("switches focus to the other pane in dual pane mode", keySwitchPane),
+		"",
+		usageKeyLine("opens a new tab in the current directory", keyNewTab),
+		usageKeyLine("closes the current tab", keyCloseTab),
+		usageKeyLine("moves to the next tab", keyNextTab),
+		usageKeyLine("moves to the previous tab", keyPrevTab),
+		"",
 		usageKeyLine("dismisses errors", keyDismissError),
 		usageKeyLine("quits the application with no return value", keyQuit),
 	}
@@ -70,6 +119,38 @@ func commands() string {
 	return fmt.Sprintf(usage, strings.Join(cmds, "\n"))
 }
 
+func exCommandsUsage() string {
+	pad := 22
+
+	usage := `
+	----------------
+	| Command line |
+	----------------
+
+	Commands are entered after ":" and can be abbreviated to any unique prefix.
+	A number moves the cursor to the entry with that number.
+	The options of :set are %s.
+
+%s
+`
+	cmds := []string{}
+	for _, cmd := range exCommands {
+		cmdStr := strings.TrimSpace(cmd.name + " " + cmd.args)
+		helpParts := strings.Split(cmd.help, "\n")
+		cmds = append(cmds, fmt.Sprintf("\t:%s%s%s", cmdStr, strings.Repeat(" ", pad-len(cmdStr)), helpParts[0]))
+		for _, helpPart := range helpParts[1:] {
+			cmds = append(cmds, fmt.Sprintf("\t %s%s", strings.Repeat(" ", pad), helpPart))
+		}
+	}
+
+	options := []string{}
+	for _, opt := range exOptions {
+		options = append(options, opt.name)
+	}
+
+	return fmt.Sprintf(usage, strings.Join(options, ", "), strings.Join(cmds, "\n"))
+}
+
 func flags() string {
 	pad := 25
 
@@ -99,12 +180,26 @@ func flags() string {
 		usageFlagLine("start in search mode", flagSearch, flagSearchShort),
 		"",
 		usageFlagLine("return output suitable for pipe and subshell usage", flagPipe),
+		usageFlagLine("write the final directory to the following file on exit", flagChooseDir),
+		usageFlagLine("write the returned path(s) to the following file on exit,\none path per line", flagChooseFiles),
+		usageFlagLine("return marked paths in the order they were marked instead of sorted", flagMarkOrder),
+		usageFlagLine("return the paths of the following saved selection set without\nstarting the program", flagSet),
 		"",
 		usageFlagLine("toggle on following symlinks at startup", flagFollowSymlinks, flagFollowSymlinksShort),
 		usageFlagLine("toggle on showing hidden files at startup", flagHidden, flagHiddenShort),
 		usageFlagLine("toggle on list mode at startup", flagList, flagListShort),
+		usageFlagLine("toggle on hiding files ignored by git at startup", flagHideGitignore, flagHideGitignoreShort),
+		usageFlagLine("toggle on computing total directory sizes at startup", flagDirSizes),
+		usageFlagLine("sort by the following value (name, size, or time)", flagSort),
+		usageFlagLine("toggle on disk usage mode at startup", flagDiskUsage, flagDiskUsageShort),
+		usageFlagLine("toggle on tree mode at startup", flagTree, flagTreeShort),
+		usageFlagLine("toggle on columns mode at startup", flagColumns, flagColumnsShort),
+		usageFlagLine("toggle on dual pane mode at startup", flagDual),
+		usageFlagLine("select the entry whose label is typed in jump mode instead of moving\nthe cursor to it", flagJumpSelect),
 		"",
 		usageFlagLine("toggle off color output", flagNoColor),
+		usageFlagLine("toggle off git status markers", flagNoGit),
+		usageFlagLine("toggle off mouse support", flagNoMouse),
 		usageFlagLine("toggle off bottom status bar menu", flagNoStatusBar),
 		usageFlagLine("toggle off trailing annotators", flagNoTrailing),
 		"",
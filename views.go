@@ -7,41 +7,88 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 )
 
+// entryFilter is the reason an entry is not displayed.
+type entryFilter int
+
+const (
+	entryFilterNone   entryFilter = iota
+	entryFilterHidden             // Hidden file or ignored by git while those are hidden.
+	entryFilterSearch             // Entry of the current directory not matching the search.
+)
+
+// filterEntry returns the reason a listed entry is not displayed, checking the hidden filters
+// before the search.
+func (m *model) filterEntry(ent *entry) entryFilter {
+	if !m.modeHidden && ent.hasMode(entryModeHidden) {
+		return entryFilterHidden
+	}
+	if m.modeHideGitignore && m.gitignored(ent) {
+		return entryFilterHidden
+	}
+	// The search applies to the entries of the current directory.
+	if m.search.value != "" && ent.depth == 0 && !strings.HasPrefix(ent.Name(), m.search.value) {
+		return entryFilterSearch
+	}
+	return entryFilterNone
+}
+
 func (m *model) normalView() string {
 	var (
-		updateCache     = newCacheItem() // Cache for storing the current state as it is constructed.
-		displayNames    = []*displayName{}
-		displayNameOpts = m.displayNameOpts()
-		displayed       = 0
-		validEntries    = 0
+		updateCache      = newCacheItem() // Cache for storing the current state as it is constructed.
+		displayedEntries = []*entry{}
+		displayNames     = []*displayName{}
+		displayNameOpts  = m.displayNameOpts()
+		displayed        = 0
+		validEntries     = 0
+		filteredDepth    = -1 // Depth of a filtered tree entry, whose descendants are also filtered.
 	)
 
-	// Construct display names from filtered entries and populate a new cache mapping between them.
+	// Filter entries and populate a new cache mapping between entry and display indexes.
 	for entryIdx, ent := range m.entries {
-		// Filter hidden files.
-		if !m.modeHidden && ent.hasMode(entryModeHidden) {
-			continue
-		}
-
-		validEntries++
-
-		// Filter for search.
-		if m.search != "" {
-			if !strings.HasPrefix(ent.Name(), m.search) {
+		if filteredDepth >= 0 {
+			if ent.depth > filteredDepth {
 				continue
 			}
+			filteredDepth = -1
+		}
+
+		filter := m.filterEntry(ent)
+		if filter != entryFilterHidden {
+			validEntries++
+		}
+		if filter != entryFilterNone {
+			filteredDepth = ent.depth
+			continue
 		}
 
-		displayNames = append(displayNames, newDisplayName(ent, displayNameOpts...))
+		displayedEntries = append(displayedEntries, ent)
 		updateCache.addIndexPair(&indexPair{entry: entryIdx, display: displayed})
 		displayed++
 	}
 
+	// Construct display names, including indentation guides for expanded tree levels.
+	var guides []string
+	if m.modeTree {
+		guides = treeGuides(displayedEntries)
+	}
+	for dispIdx, ent := range displayedEntries {
+		opts := displayNameOpts
+		if guides != nil {
+			opts = append(opts[:len(opts):len(opts)], displayNameWithTreeGuide(guides[dispIdx]))
+		}
+		displayNames = append(displayNames, newDisplayName(ent, opts...))
+	}
+
+	// Carry over the tree expansion state.
+	if cache, found := m.pathCache[m.path]; found {
+		updateCache.expanded = cache.expanded
+	}
+
 	if validEntries == 0 {
 		return m.locationBar() + "\n\n\t(no entries)\n"
 	}
 
-	if m.modeSearch || m.search != "" {
+	if m.modeSearch || m.search.value != "" {
 		if displayed == 0 && validEntries > 0 {
 			return m.locationBar() + "\n\n\t(no matching entries)\n"
 		}
@@ -49,12 +96,19 @@ func (m *model) normalView() string {
 
 	// Grid layout for display.
 	var (
-		width     = m.width
+		width     = m.paneWidth()
 		height    = m.height - 2 // Account for location and status bars.
 		gridNames [][]string
 		layout    gridLayout
 	)
-	if m.modeList {
+	if m.showTabBar() {
+		height--
+	}
+	if m.modeColumns {
+		_, width, _ = m.columnsWidths()
+		width--
+	}
+	if m.modeList || m.modeDiskUsage || m.modeTree || m.modeColumns {
 		gridNames, layout = gridSingleColumn(displayNames, width, height)
 	} else {
 		gridNames, layout = gridMultiColumn(displayNames, width, height)
@@ -72,6 +126,15 @@ func (m *model) normalView() string {
 			}
 		}
 	}
+	if m.focusPath != "" {
+		for dispIdx, ent := range displayedEntries {
+			if ent.path() == m.focusPath {
+				updateCursorPosition = newPositionFromIndex(dispIdx, layout.rows)
+				break
+			}
+		}
+		m.focusPath = ""
+	}
 
 	// Update the cache.
 	updateCache.setPosition(updateCursorPosition)
@@ -83,40 +146,79 @@ func (m *model) normalView() string {
 	m.displayed = displayed
 	m.columns = layout.columns
 	m.rows = layout.rows
+	m.columnLens = layout.maxColumnLen
 	m.setCursor(updateCursorPosition)
 	if m.c >= m.columns || m.r > m.rows {
 		m.resetCursor()
 	}
-	if err := m.reloadMarks(); err != nil {
-		m.setError(err, "failed to update marks")
-	}
 
 	// Render entry names in grid.
 	gridOutput := make([]string, layout.rows)
 	for row := 0; row < layout.rows; row++ {
 		for col := 0; col < layout.columns; col++ {
-			if col == m.c && row == m.r {
+			dispIdx := index(col, row, layout.rows)
+			name := gridNames[col][row]
+			// Jump labels replace the cursor so that the entry under it can be selected by its label.
+			if label, ok := m.displayedJumpLabel(dispIdx); ok {
+				gridOutput[row] += renderJumpLabel(label, name)
+			} else if col == m.c && row == m.r {
 				if m.marked() {
-					gridOutput[row] += cursorRendererSelectedMarked.Render(gridNames[col][row])
+					gridOutput[row] += cursorRendererSelectedMarked.Render(name)
 				} else {
-					gridOutput[row] += cursorRendererSelected.Render(gridNames[col][row])
+					gridOutput[row] += cursorRendererSelected.Render(name)
 				}
+			} else if m.inVisualRange(dispIdx) {
+				gridOutput[row] += cursorRendererVisual.Render(name)
+			} else if m.markedIndex(dispIdx) {
+				gridOutput[row] += cursorRendererMarked.Render(name)
 			} else {
-				if m.markedIndex(index(col, row, layout.rows)) {
-					gridOutput[row] += cursorRendererMarked.Render(gridNames[col][row])
-				} else {
-					gridOutput[row] += cursorRendererNormal.Render(gridNames[col][row])
-				}
+				gridOutput[row] += cursorRendererNormal.Render(name)
 			}
 		}
 	}
 
+	if m.modeColumns {
+		gridOutput = m.columnsView(gridOutput)
+	}
+
 	// Construct the final view.
 	output := []string{m.locationBar()}
 	output = append(output, gridOutput...)
 	return strings.Join(output, "\n")
 }
 
+// dualView displays the panes side by side with the location bar of the pane without focus dimmed.
+func (m *model) dualView() string {
+	focused := m.pane
+	views := [][]string{}
+	for _, p := range m.panes {
+		m.paneInactive = p != focused
+		m.inPane(p, func() {
+			views = append(views, strings.Split(m.normalView(), "\n"))
+		})
+	}
+	m.paneInactive = false
+
+	width := m.paneWidth()
+	output := make([]string, max(len(views[0]), len(views[1])))
+	for i := range output {
+		output[i] = fitWidth(rowAt(views[0], i), width) + " " + fitWidth(rowAt(views[1], i), width)
+	}
+	return strings.Join(output, "\n")
+}
+
+// viewHeight returns the number of rows available between the location bar and the status bar.
+func (m *model) viewHeight() int {
+	height := m.height - 1
+	if !m.hideStatusBar {
+		height -= 2
+	}
+	if m.showTabBar() {
+		height--
+	}
+	return height
+}
+
 func (m *model) debugView() string {
 	output := barRendererOK.Render("No errors")
 	if m.modeError {
@@ -156,6 +258,49 @@ func (m *model) statusBar() string {
 			statusBarItem(fmt.Sprintf(`"%s": complete`, keyStringFirst(keyTab))),
 			statusBarItem(fmt.Sprintf(`"%s": normal mode`, keyStringFirst(keyEsc))),
 		}
+	} else if m.modePrompt {
+		mode = m.prompt.mode
+		cmds = []statusBarItem{
+			statusBarItem(fmt.Sprintf(`"%s": submit`, keyStringFirst(keySelect))),
+			statusBarItem(fmt.Sprintf(`"%s": history`, keyStringFirst(keyHistoryPrev))),
+			statusBarItem(fmt.Sprintf(`"%s": cancel`, keyStringFirst(keyEsc))),
+		}
+	} else if m.modeJump {
+		mode = "JUMP"
+		cmds = []statusBarItem{
+			statusBarItem("type a label to jump"),
+			statusBarItem(fmt.Sprintf(`"%s": cancel`, keyStringFirst(keyEsc))),
+		}
+	} else if m.modeVisual {
+		mode = "VISUAL"
+		cmds = []statusBarItem{
+			statusBarItem(fmt.Sprintf(`"%s": mark range`, keyStringFirst(keyVisual))),
+			statusBarItem(fmt.Sprintf(`"%s": toggle range`, keyStringFirst(keyMark))),
+			statusBarItem(fmt.Sprintf(`"%s": cancel`, keyStringFirst(keyEsc))),
+		}
+	} else if m.modeSets && m.setName != "" {
+		mode = "SET"
+		cmds = []statusBarItem{
+			statusBarItem(fmt.Sprintf(`"%s": remove`, keyStringFirst(keySetDelete))),
+			statusBarItem(fmt.Sprintf(`"%s": add marked`, keyStringFirst(keySetAdd))),
+			statusBarItem(fmt.Sprintf(`"%s": remove missing`, keyStringFirst(keySetPrune))),
+			statusBarItem(fmt.Sprintf(`"%s": sets`, keyStringFirst(keyEsc))),
+		}
+	} else if m.modeSets {
+		mode = "SETS"
+		cmds = []statusBarItem{
+			statusBarItem(fmt.Sprintf(`"%s": mark`, keyStringFirst(keySelect))),
+			statusBarItem(fmt.Sprintf(`"%s": edit`, keyStringFirst(keyRight))),
+			statusBarItem(fmt.Sprintf(`"%s": delete`, keyStringFirst(keySetDelete))),
+			statusBarItem(fmt.Sprintf(`"%s": normal mode`, keyStringFirst(keyEsc))),
+		}
+	} else if m.modeBasket {
+		mode = "BASKET"
+		cmds = []statusBarItem{
+			statusBarItem(fmt.Sprintf(`"%s": go to`, keyStringFirst(keySelect))),
+			statusBarItem(fmt.Sprintf(`"%s": unmark`, keyStringFirst(keyMark))),
+			statusBarItem(fmt.Sprintf(`"%s": normal mode`, keyStringFirst(keyEsc))),
+		}
 	} else if m.modeHelp {
 		mode = "HELP"
 		cmds = []statusBarItem{
@@ -222,11 +367,35 @@ func (m *model) locationBar() string {
 	}
 
 	locationBar := barRendererLocation.Render(m.location())
-	if m.modeSearch || m.search != "" {
+	if m.paneInactive {
+		locationBar = barRendererLocationInactive.Render(m.location())
+	}
+	if status := m.gitStatus(); status != nil {
+		locationBar += barRendererInfo.Render(" " + gitBranchInfo(status) + " ")
+	}
+	if m.modeMarks {
+		locationBar += barRendererInfo.Render(fmt.Sprintf(" %d marked ", len(m.marks)))
+	}
+	if m.modeDiskUsage {
+		locationBar += barRendererInfo.Render(fmt.Sprintf(" disk usage: %s ", m.diskUsageStatus()))
+	} else if m.sortMode != sortModeName {
+		locationBar += barRendererInfo.Render(fmt.Sprintf(" sort: %s ", m.sortMode))
+	}
+	if m.modeSearch || m.search.value != "" {
 		if m.path != fileSeparator {
-			locationBar += barRendererSearch.Render(fileSeparator + m.search)
+			if m.modeSearch && !m.paneInactive {
+				locationBar += m.search.render(barRendererSearch, fileSeparator)
+			} else {
+				locationBar += barRendererSearch.Render(fileSeparator + m.search.value)
+			}
 		}
 	}
+	if m.modePrompt && !m.paneInactive {
+		locationBar += " " + m.prompt.input.render(barRendererPrompt, m.prompt.label)
+	}
+	if m.info != "" && !m.paneInactive {
+		locationBar += " " + barRendererOK.Render(" "+m.info+" ")
+	}
 	return locationBar
 }
 
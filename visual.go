@@ -0,0 +1,89 @@
+package main
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// startVisual enters visual mode, anchoring the range of entries to mark at the cursor.
+func (m *model) startVisual() {
+	m.modeVisual = true
+	m.visualAnchor = m.displayIndex()
+}
+
+// inVisualRange reports whether a display index is between the visual anchor and the cursor in
+// display (column-major) order.
+func (m *model) inVisualRange(dispIdx int) bool {
+	if !m.modeVisual {
+		return false
+	}
+	lo, hi := m.visualAnchor, m.displayIndex()
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	return dispIdx >= lo && dispIdx <= hi
+}
+
+// visualPaths returns the paths of the entries in the visual range.
+func (m *model) visualPaths() []string {
+	paths := []string{}
+	for i := 0; i < m.displayed; i++ {
+		if !m.inVisualRange(i) {
+			continue
+		}
+		if ent, ok := m.displayedEntry(i); ok {
+			paths = append(paths, ent.path())
+		}
+	}
+	return paths
+}
+
+// markVisual marks the entries in the visual range, or toggles their marks, and exits visual mode.
+func (m *model) markVisual(toggle bool) {
+	for _, path := range m.visualPaths() {
+		if _, marked := m.marks[path]; marked && toggle {
+			m.unmark(path)
+		} else if !marked {
+			m.mark(path)
+		}
+	}
+	m.modeVisual = false
+}
+
+func actionModeVisual(m *model, msg tea.KeyMsg, esc bool) actionResult {
+	switch {
+
+	case esc || key.Matches(msg, keyEsc):
+		m.modeVisual = false
+
+	case key.Matches(msg, keyVisual), key.Matches(msg, keySelect):
+		m.markVisual(false)
+
+	case key.Matches(msg, keyMark):
+		m.markVisual(true)
+
+	// Left and right move within the grid only, as in the columns and tree modes they change the
+	// displayed entries that the anchor indexes.
+	case key.Matches(msg, keyLeft):
+		for range m.repeat() {
+			m.moveLeft()
+		}
+
+	case key.Matches(msg, keyRight):
+		for range m.repeat() {
+			m.moveRight()
+		}
+
+	// Allow cursor movement and returning.
+	case key.Matches(msg, keyUp), key.Matches(msg, keyDown),
+		key.Matches(msg, keyFirst), key.Matches(msg, keyLast), key.Matches(msg, keyRowStart), key.Matches(msg, keyRowEnd),
+		key.Matches(msg, keyScreenTop), key.Matches(msg, keyScreenMiddle), key.Matches(msg, keyScreenBottom),
+		key.Matches(msg, keyColumnPrev), key.Matches(msg, keyColumnNext), key.Matches(msg, keyPageDown), key.Matches(msg, keyPageUp),
+		key.Matches(msg, keyReturnSelected), key.Matches(msg, keyReturnDirectory):
+		return newActionResultNoop()
+
+	}
+
+	// Unconditional return to disable all other functionality.
+	return newActionResult(nil)
+}
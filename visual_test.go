@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestVisualGridKeys(t *testing.T) {
+	for _, mode := range []string{"columns", "tree"} {
+		m := newModel()
+		m.path = "/nav/test"
+		m.rows, m.columns, m.displayed = 4, 2, 8
+		m.modeColumns = mode == "columns"
+		m.modeTree = mode == "tree"
+		m.setCursor(&position{c: 1, r: 2})
+		m.startVisual()
+
+		m.update(tea.KeyMsg{Type: tea.KeyLeft})
+		if m.c != 0 || m.r != 2 || m.path != "/nav/test" || !m.modeVisual {
+			t.Fatalf("expected %s mode to move to (0, 2) in visual mode, got (%d, %d) in %s with visual %t",
+				mode, m.c, m.r, m.path, m.modeVisual)
+		}
+		m.update(tea.KeyMsg{Type: tea.KeyRight})
+		if m.c != 1 || m.r != 2 {
+			t.Fatalf("expected %s mode to move to (1, 2), got (%d, %d)", mode, m.c, m.r)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/dkaslovsky/nav/internal/clipboard"
+)
+
+type yankMsg struct {
+	count    int
+	sequence string // Escape sequence copying the paths, written to the terminal by the view.
+	err      error
+}
+
+// yank returns a command that copies paths to the system clipboard, formatted as they are returned
+// on exit. The escape sequence for the terminal is returned to be written as part of the view
+// since writing it from the command would interleave with the program's rendering.
+func yank(paths []string) tea.Cmd {
+	text := formatPaths(paths)
+	return func() tea.Msg {
+		return yankMsg{
+			count:    len(paths),
+			sequence: clipboard.Sequence(text),
+			err:      clipboard.CopyLocal(text),
+		}
+	}
+}